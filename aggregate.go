@@ -0,0 +1,222 @@
+package flow
+
+import "cmp"
+
+// Min returns the smallest element of a flow of ordered values, using cmp.Less ordering.
+// The boolean is false for an empty flow, distinguishing it from a genuine zero value.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	if lowest, ok := flow.Min(flow.Of(3, 1, 2)); ok {
+//	    fmt.Println(lowest) // 1
+//	}
+func Min[T cmp.Ordered, R any](f Flow[T, R]) (T, bool) {
+	var result T
+	found := false
+	for k := range f.source {
+		if !found || cmp.Less(k, result) {
+			result = k
+			found = true
+		}
+	}
+	return result, found
+}
+
+// Max returns the largest element of a flow of ordered values, using cmp.Less ordering.
+// The boolean is false for an empty flow, distinguishing it from a genuine zero value.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	if highest, ok := flow.Max(flow.Of("pear", "apple", "plum")); ok {
+//	    fmt.Println(highest) // plum
+//	}
+func Max[T cmp.Ordered, R any](f Flow[T, R]) (T, bool) {
+	var result T
+	found := false
+	for k := range f.source {
+		if !found || cmp.Less(result, k) {
+			result = k
+			found = true
+		}
+	}
+	return result, found
+}
+
+// MinBy returns the element whose key is smallest.
+// The key is computed once per element. When several elements share the smallest
+// key, the first one encountered is returned.
+// The boolean is false for an empty flow.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	youngest, ok := flow.MinBy(flow.NewFlow(people), func(p Person) int { return p.Age })
+func MinBy[T, R any, K cmp.Ordered](f Flow[T, R], key func(T) K) (T, bool) {
+	var result T
+	var best K
+	found := false
+	for k := range f.source {
+		if current := key(k); !found || cmp.Less(current, best) {
+			result, best = k, current
+			found = true
+		}
+	}
+	return result, found
+}
+
+// MaxBy returns the element whose key is largest.
+// The key is computed once per element. When several elements share the largest
+// key, the first one encountered is returned.
+// The boolean is false for an empty flow.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	oldest, ok := flow.MaxBy(flow.NewFlow(people), func(p Person) int { return p.Age })
+func MaxBy[T, R any, K cmp.Ordered](f Flow[T, R], key func(T) K) (T, bool) {
+	var result T
+	var best K
+	found := false
+	for k := range f.source {
+		if current := key(k); !found || cmp.Less(best, current) {
+			result, best = k, current
+			found = true
+		}
+	}
+	return result, found
+}
+
+// MinElem returns the smallest element of a flow of ordered values.
+// It is an alias of Min, kept so code written against MinElem reads naturally.
+func MinElem[T cmp.Ordered, R any](f Flow[T, R]) (T, bool) {
+	return Min(f)
+}
+
+// MaxElem returns the largest element of a flow of ordered values.
+// It is an alias of Max, kept so code written against MaxElem reads naturally.
+func MaxElem[T cmp.Ordered, R any](f Flow[T, R]) (T, bool) {
+	return Max(f)
+}
+
+// AverageBy returns the arithmetic mean of selector applied to each element.
+// The boolean is false for an empty flow.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	avgAge, ok := flow.AverageBy(flow.NewFlow(people), func(p Person) float64 {
+//	    return float64(p.Age)
+//	})
+func AverageBy[T, R any](f Flow[T, R], selector func(T) float64) (float64, bool) {
+	sum := 0.0
+	count := 0
+	for k := range f.source {
+		sum += selector(k)
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum returns the arithmetic sum of a flow of numbers.
+// An empty flow sums to zero.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	total := flow.Sum(flow.Of(1.5, 2.5, 3.0)) // 7.0
+func Sum[T Number, R any](f Flow[T, R]) T {
+	var sum T
+	for k := range f.source {
+		sum += k
+	}
+	return sum
+}
+
+// NumericStats summarizes a flow of numbers.
+// For an empty flow all fields hold their zero values.
+type NumericStats[T Number] struct {
+	Count int
+	Sum   T
+	Min   T
+	Max   T
+	Mean  float64
+}
+
+// CollectWithStats gathers all elements into a slice and summarizes them in the same pass.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	values, stats := flow.CollectWithStats(flow.Of(3, 1, 4, 1, 5))
+//	// values: [3 1 4 1 5]
+//	// stats: {Count: 5, Sum: 14, Min: 1, Max: 5, Mean: 2.8}
+func CollectWithStats[T Number, R any](f Flow[T, R]) ([]T, NumericStats[T]) {
+	result := make([]T, 0, 16)
+	var stats NumericStats[T]
+	for k := range f.source {
+		if stats.Count == 0 || k < stats.Min {
+			stats.Min = k
+		}
+		if stats.Count == 0 || k > stats.Max {
+			stats.Max = k
+		}
+		stats.Sum += k
+		stats.Count++
+		result = append(result, k)
+	}
+	if stats.Count > 0 {
+		stats.Mean = float64(stats.Sum) / float64(stats.Count)
+	}
+	return result, stats
+}
+
+// MapReduce transforms each element and folds the results in a single pass.
+// It is equivalent to MapTo followed by Reduce, without the intermediate stage.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	sumOfSquares := flow.MapReduce(flow.Range(1, 4),
+//	    func(x int) int { return x * x },
+//	    0,
+//	    func(acc, sq int) int { return acc + sq },
+//	) // 14
+func MapReduce[T, R, M, A any](f Flow[T, R], mapper func(T) M, initial A, reducer func(A, M) A) A {
+	result := initial
+	for k := range f.source {
+		result = reducer(result, mapper(k))
+	}
+	return result
+}
+
+// ReduceScan folds the stream like Reduce while also recording every intermediate accumulator.
+// steps holds the accumulator after each element, so its last entry equals final
+// for a non-empty flow; for an empty flow final is initial and steps is empty.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	total, running := flow.ReduceScan(flow.Of(1, 2, 3, 4), 0, func(acc, x int) int { return acc + x })
+//	// total: 10
+//	// running: [1, 3, 6, 10]
+func ReduceScan[T, R, A any](f Flow[T, R], initial A, acc func(A, T) A) (final A, steps []A) {
+	final = initial
+	steps = make([]A, 0, 16)
+	for k := range f.source {
+		final = acc(final, k)
+		steps = append(steps, final)
+	}
+	return final, steps
+}
@@ -0,0 +1,70 @@
+package flow
+
+// ComparableFlow is a Flow whose element type is known to be comparable.
+// It embeds Flow, so every Flow method is available, and adds methods such as
+// Distinct and ToSet that need comparable elements and therefore cannot be
+// defined on Flow itself. Embedded Flow methods return a plain Flow; wrap the
+// result with AsComparable again to keep chaining comparable methods.
+//
+// Example:
+//
+//	flow.AsComparable(flow.Of(1, 1, 2, 3, 3)).Dedup().Collect() // [1, 2, 3]
+type ComparableFlow[T comparable] struct {
+	Flow[T, T]
+}
+
+// AsComparable converts a Flow with comparable elements into a ComparableFlow.
+// The comparable constraint is checked at compile time, so the conversion cannot fail.
+//
+// Example:
+//
+//	words := flow.AsComparable(flow.Of("a", "b", "a"))
+//	set := words.ToSet() // map[a:{} b:{}]
+func AsComparable[T comparable, R any](f Flow[T, R]) ComparableFlow[T] {
+	return ComparableFlow[T]{
+		Flow: Flow[T, T]{
+			source: func(yield func(T, T) bool) {
+				for k := range f.source {
+					if !yield(k, k) {
+						return
+					}
+				}
+			},
+		},
+	}
+}
+
+// Distinct removes duplicate elements from the stream.
+// It behaves like the standalone Distinct function.
+//
+// Example:
+//
+//	flow.AsComparable(flow.Of(1, 2, 1, 3)).Distinct().Collect() // [1, 2, 3]
+func (f ComparableFlow[T]) Distinct() ComparableFlow[T] {
+	return ComparableFlow[T]{Flow: Distinct(f.Flow)}
+}
+
+// Dedup removes consecutive duplicate elements, keeping the first of each run.
+// Unlike Distinct, it only remembers the previous element, so memory stays constant.
+//
+// Example:
+//
+//	flow.AsComparable(flow.Of(1, 1, 2, 1, 1)).Dedup().Collect() // [1, 2, 1]
+func (f ComparableFlow[T]) Dedup() ComparableFlow[T] {
+	return ComparableFlow[T]{Flow: Changed(f.Flow, func(a, b T) bool { return a == b })}
+}
+
+// ToSet collects the elements into a set.
+// This is a TERMINAL operation - it consumes the entire stream.
+//
+// Example:
+//
+//	set := flow.AsComparable(flow.Of("a", "b", "a")).ToSet()
+//	_, ok := set["a"] // true
+func (f ComparableFlow[T]) ToSet() map[T]struct{} {
+	set := make(map[T]struct{})
+	for k := range f.source {
+		set[k] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,160 @@
+package flow
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// ToRows converts each element into a positional row of values.
+// The result is shaped for database batch inserts, where each row supplies
+// the arguments for one set of placeholders. Combine with Chunk to insert
+// in fixed-size batches.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	rows := flow.ToRows(flow.NewFlow(people), func(p Person) []any {
+//	    return []any{p.Name, p.Age}
+//	})
+//	for _, row := range rows {
+//	    stmt.Exec(row...)
+//	}
+func ToRows[T, R any](f Flow[T, R], rowFunc func(T) []any) [][]any {
+	rows := make([][]any, 0, 16)
+	for k := range f.source {
+		rows = append(rows, rowFunc(k))
+	}
+	return rows
+}
+
+// ToBitset collects a flow of integers into a bitset covering the range [0, max].
+// Bit i of the result (word i/64, bit i%64) is set when i appears in the flow.
+// Values outside [0, max] are skipped.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	bits := flow.ToBitset(flow.Of(1, 3, 64), 100)
+//	// bits[0] == 0b1010, bits[1] == 1
+func ToBitset[R any](f Flow[int, R], max int) []uint64 {
+	if max < 0 {
+		panic("bitset max must not be negative")
+	}
+
+	bits := make([]uint64, max/64+1)
+	for v := range f.source {
+		if v < 0 || v > max {
+			continue
+		}
+		bits[v/64] |= 1 << (v % 64)
+	}
+	return bits
+}
+
+// ToCSV encodes the stream as CSV using encoding/csv.
+// The header row is written first when it is non-nil, followed by one record per
+// element as returned by rowFunc. Encoding stops at the first write error.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	data, err := flow.ToCSV(flow.NewFlow(people), func(p Person) []string {
+//	    return []string{p.Name, strconv.Itoa(p.Age)}
+//	}, []string{"name", "age"})
+func ToCSV[T, R any](f Flow[T, R], rowFunc func(T) []string, header []string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if header != nil {
+		if err := w.Write(header); err != nil {
+			return nil, err
+		}
+	}
+	for k := range f.source {
+		if err := w.Write(rowFunc(k)); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ToJSONGroups groups elements by key and marshals the groups as a JSON object
+// mapping each key to the array of its elements, in stream order.
+// Object keys are sorted by encoding/json, so the output is deterministic.
+// K must be a string or integer kind, or implement encoding.TextMarshaler;
+// other key types make json.Marshal return an error.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	body, err := flow.ToJSONGroups(flow.NewFlow(orders), func(o Order) string { return o.Status })
+//	// {"pending":[...],"shipped":[...]}
+func ToJSONGroups[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) ([]byte, error) {
+	return json.Marshal(GroupBy(f, keyFunc))
+}
+
+// ToGob writes every element to w with encoding/gob, one value at a time, so the
+// stream never has to be held in memory. Encoding stops at the first error.
+// Read the result back with FromGob.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	file, _ := os.Create("people.gob")
+//	defer file.Close()
+//	if err := flow.ToGob(flow.NewFlow(people), file); err != nil {
+//	    return err
+//	}
+func ToGob[T, R any](f Flow[T, R], w io.Writer) error {
+	enc := gob.NewEncoder(w)
+	for k := range f.source {
+		if err := enc.Encode(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FromGob creates a Flow that lazily decodes gob values of type T from r until EOF,
+// together with a function reporting the decode error, if any. As with FilterE,
+// a decode error ends the flow early; call the returned function after consumption
+// to tell a clean EOF apart from a failure.
+// The reader is shared, so the flow should only be consumed once; consuming it again
+// continues from where the previous consumption stopped.
+//
+// Example:
+//
+//	people, errFn := flow.FromGob[Person](file)
+//	result := people.Collect()
+//	if err := errFn(); err != nil {
+//	    return err
+//	}
+func FromGob[T any](r io.Reader) (Flow[T, T], func() error) {
+	dec := gob.NewDecoder(r)
+	var err error
+	decoded := Flow[T, T]{
+		source: func(yield func(T, T) bool) {
+			err = nil
+			for {
+				var val T
+				if decErr := dec.Decode(&val); decErr != nil {
+					if !errors.Is(decErr, io.EOF) {
+						err = decErr
+					}
+					return
+				}
+				if !yield(val, val) {
+					return
+				}
+			}
+		},
+	}
+	return decoded, func() error { return err }
+}
@@ -3,6 +3,8 @@ package flow
 import (
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
 	"iter"
 
@@ -13,6 +15,11 @@ import (
 // The zero value is not usable; use constructor functions like From, Range, etc.
 type Flow[T, R any] struct {
 	source iter.Seq2[T, R]
+
+	// values holds the backing slice of flows created by NewFlow.
+	// It is only meaningful when sliceBacked is set.
+	values      []T
+	sliceBacked bool
 }
 
 // NewFlow creates a new Flow from a slice.
@@ -31,6 +38,8 @@ func NewFlow[T any](values []T) Flow[T, T] {
 				}
 			}
 		},
+		values:      values,
+		sliceBacked: true,
 	}
 }
 
@@ -270,6 +279,10 @@ func Infinite[T any](generator func(index int) T) Flow[T, T] {
 
 // FromChannel creates a Flow from a channel.
 // The Flow will consume values from the channel until it's closed.
+// The channel is read on the consumer's goroutine and no goroutine is started,
+// so early-terminating operations such as First, Take and FindFirst simply stop
+// receiving once they have their result. Unread values stay in the channel and
+// the channel is not closed.
 //
 // Example:
 //
@@ -293,6 +306,24 @@ func FromChannel[T any](ch <-chan T) Flow[T, T] {
 	}
 }
 
+// FromRunes creates a Flow of the runes of a string.
+// Multi-byte UTF-8 sequences are decoded into single runes.
+//
+// Example:
+//
+//	flow.FromRunes("héllo").Count() // Returns 5
+func FromRunes(s string) Flow[rune, rune] {
+	return Flow[rune, rune]{
+		source: func(yield func(rune, rune) bool) {
+			for _, r := range s {
+				if !yield(r, r) {
+					return
+				}
+			}
+		},
+	}
+}
+
 // Filter returns a Flow containing only elements that match the predicate.
 // This is a lazy operation - the predicate is not called until the stream is consumed.
 //
@@ -313,6 +344,62 @@ func (f Flow[T, R]) Filter(predicate func(T) bool) Flow[T, R] {
 	}
 }
 
+// FilterAll returns a Flow containing only elements that match every predicate.
+// All predicates run in a single stage, in the order given, and evaluation stops at
+// the first predicate that returns false. This avoids the per-element overhead
+// of chaining several Filter calls. With no predicates every element is kept.
+//
+// Example:
+//
+//	flow.Range(1, 100).FilterAll(
+//	    func(x int) bool { return x%2 == 0 },
+//	    func(x int) bool { return x%3 == 0 },
+//	) // Stream of 6, 12, 18, ...
+func (f Flow[T, R]) FilterAll(predicates ...func(T) bool) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+		elements:
+			for k, v := range f.source {
+				for _, predicate := range predicates {
+					if !predicate(k) {
+						continue elements
+					}
+				}
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// FilterAny returns a Flow containing only elements that match at least one predicate.
+// Predicates run in the order given, and evaluation stops at the first predicate
+// that returns true. With no predicates nothing matches, so the flow is empty.
+//
+// Example:
+//
+//	flow.Range(1, 20).FilterAny(
+//	    func(x int) bool { return x%5 == 0 },
+//	    func(x int) bool { return x%7 == 0 },
+//	) // Stream of 5, 7, 10, 14, 15
+func (f Flow[T, R]) FilterAny(predicates ...func(T) bool) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			for k, v := range f.source {
+				for _, predicate := range predicates {
+					if predicate(k) {
+						if !yield(k, v) {
+							return
+						}
+						break
+					}
+				}
+			}
+		},
+	}
+}
+
 // Map transforms each element using the provided mapper function.
 // Returns Flow[any, any] to allow chaining with any output type.
 // Optimized with minimal type assertions for common patterns.
@@ -433,6 +520,33 @@ func (f Flow[T, R]) TakeWhile(predicate func(T) bool) Flow[T, R] {
 	}
 }
 
+// MapUntil transforms each element with mapper until the mapper signals a stop.
+// The mapper returns the transformed element and whether to continue; on the
+// first false the stream ends and that element is not emitted.
+// This is a lazy operation - the source is not advanced past the stopping element.
+//
+// Example:
+//
+//	// Double values until one would exceed 100
+//	flow.Of(10, 30, 60, 5).MapUntil(func(x int) (int, bool) {
+//	    return x * 2, x*2 <= 100
+//	}) // Produces: 20, 60
+func (f Flow[T, R]) MapUntil(mapper func(T) (T, bool)) Flow[T, T] {
+	return Flow[T, T]{
+		source: func(yield func(T, T) bool) {
+			for k := range f.source {
+				res, ok := mapper(k)
+				if !ok {
+					return
+				}
+				if !yield(res, res) {
+					return
+				}
+			}
+		},
+	}
+}
+
 // SkipWhile skips elements while the predicate is true.
 // This is a lazy operation - starts yielding when predicate returns false.
 //
@@ -540,6 +654,138 @@ func (f Flow[T, R]) Peek(action func(T)) Flow[T, R] {
 	}
 }
 
+// PeekIndexed performs an action on each element together with its 0-based position.
+// Elements pass through unchanged. Like Peek, the action is called lazily as
+// elements are consumed.
+//
+// Example:
+//
+//	flow.NewFlow(rows).
+//	    PeekIndexed(func(i int, row Row) { log.Printf("row %d: %v", i, row) }).
+//	    Collect()
+func (f Flow[T, R]) PeekIndexed(action func(index int, val T)) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			i := 0
+			for k, v := range f.source {
+				action(i, k)
+				i++
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// PeekEvery performs an action on every nth element, for sampled debugging of large flows.
+// The action runs for the elements at 0-based positions 0, n, 2n, ..., so the
+// first element is always sampled. All elements pass through unchanged.
+// Panics if n is not positive.
+//
+// Example:
+//
+//	flow.NewFlow(rows).
+//	    PeekEvery(1000, func(row Row) { log.Printf("sample: %v", row) }).
+//	    Collect()
+func (f Flow[T, R]) PeekEvery(n int, action func(T)) Flow[T, R] {
+	if n <= 0 {
+		panic("peek every step must be positive")
+	}
+
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			i := 0
+			for k, v := range f.source {
+				if i%n == 0 {
+					action(k)
+				}
+				i++
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// BeforeFirst runs an action once, right before the first element is passed downstream.
+// The action runs lazily on every consumption of the flow, and not at all if the
+// flow is never consumed or turns out to be empty. Useful for deferring resource
+// setup until data is actually needed.
+//
+// Example:
+//
+//	flow.NewFlow(queries).
+//	    BeforeFirst(func() { conn = openConnection() }).
+//	    ForEachFunc(func(q string) { conn.Exec(q) })
+func (f Flow[T, R]) BeforeFirst(action func()) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			first := true
+			for k, v := range f.source {
+				if first {
+					action()
+					first = false
+				}
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// Catch recovers from a panic raised while producing elements and continues with a fallback.
+// If the source panics mid-stream, the recovered value is passed to handler and
+// the flow returned by it is yielded in place of the remaining elements.
+// Panics raised by downstream operations are not caught.
+//
+// Example:
+//
+//	flow.FromFunc(readRecords).
+//	    Catch(func(recovered any) flow.Flow[Record, Record] {
+//	        log.Printf("source failed: %v", recovered)
+//	        return flow.NewFlow(cachedRecords)
+//	    }).
+//	    Collect()
+func (f Flow[T, R]) Catch(handler func(recovered any) Flow[T, R]) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			next, stop := iter.Pull2(f.source)
+			defer stop()
+
+			for {
+				k, v, ok, recovered := pullRecover(next)
+				if recovered != nil {
+					for k2, v2 := range handler(recovered).source {
+						if !yield(k2, v2) {
+							return
+						}
+					}
+					return
+				}
+				if !ok {
+					return
+				}
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// pullRecover advances a pulled iterator, converting a panic in the
+// underlying sequence into a returned recovered value.
+func pullRecover[T, R any](next func() (T, R, bool)) (k T, v R, ok bool, recovered any) {
+	defer func() {
+		recovered = recover()
+	}()
+	k, v, ok = next()
+	return
+}
+
 // ForEach executes the given function for each element in the stream.
 // This is a TERMINAL operation - it consumes the stream immediately.
 // Accepts ANY function through reflection for maximum flexibility.
@@ -579,6 +825,56 @@ func (f Flow[T, R]) ForEachFunc(action func(T)) {
 	}
 }
 
+// ForEachUntil executes the action for each element until the action returns true.
+// The element for which the action signals a stop is the last one consumed;
+// the source is not advanced any further. Like ForEachFunc it uses no reflection.
+// This is a TERMINAL operation.
+//
+// Example:
+//
+//	flow.NewFlow(lines).ForEachUntil(func(line string) bool {
+//	    fmt.Println(line)
+//	    return line == "END"
+//	})
+func (f Flow[T, R]) ForEachUntil(action func(T) (stop bool)) {
+	for k := range f.source {
+		if action(k) {
+			return
+		}
+	}
+}
+
+// Observe consumes the flow in the style of a reactive observer.
+// onNext is called for each element, then onComplete once the flow ends.
+// If the source panics, the recovered value is passed to onError instead and
+// onComplete is not called. As with Catch, panics raised by onNext itself are not caught.
+// This is a TERMINAL operation - it consumes the entire stream.
+//
+// Example:
+//
+//	flow.FromFunc(readEvents).Observe(
+//	    func(e Event) { handle(e) },
+//	    func() { log.Println("stream finished") },
+//	    func(err any) { log.Printf("stream failed: %v", err) },
+//	)
+func (f Flow[T, R]) Observe(onNext func(T), onComplete func(), onError func(any)) {
+	next, stop := iter.Pull2(f.source)
+	defer stop()
+
+	for {
+		k, _, ok, recovered := pullRecover(next)
+		if recovered != nil {
+			onError(recovered)
+			return
+		}
+		if !ok {
+			onComplete()
+			return
+		}
+		onNext(k)
+	}
+}
+
 // Collect gathers all elements into a slice.
 // This is a TERMINAL operation - it consumes the entire stream.
 //
@@ -593,6 +889,122 @@ func (f Flow[T, R]) Collect() []T {
 	return result
 }
 
+// CollectProgress gathers all elements into a slice, reporting progress along the way.
+// The onProgress callback receives the number of elements collected so far
+// and is invoked after every 'every' elements.
+// This is a TERMINAL operation - it consumes the entire stream.
+//
+// Example:
+//
+//	records := source.CollectProgress(1000, func(n int) {
+//	    fmt.Printf("\rLoaded %d records", n)
+//	})
+func (f Flow[T, R]) CollectProgress(every int, onProgress func(countSoFar int)) []T {
+	if every <= 0 {
+		panic("progress interval must be positive")
+	}
+
+	result := make([]T, 0, 16)
+	for k := range f.source {
+		result = append(result, k)
+		if len(result)%every == 0 {
+			onProgress(len(result))
+		}
+	}
+	return result
+}
+
+// CollectReverse gathers all elements into a slice in reverse stream order.
+// The elements are collected once and reversed in place, avoiding a separate
+// Reverse pass.
+// This is a TERMINAL operation - it consumes the entire stream.
+//
+// Example:
+//
+//	flow.Range(1, 6).CollectReverse() // Returns []int{5, 4, 3, 2, 1}
+func (f Flow[T, R]) CollectReverse() []T {
+	result := f.Collect()
+	slices.Reverse(result)
+	return result
+}
+
+// CollectIndexed gathers all elements into a map keyed by their 0-based position.
+// Useful for interop with sparse or array-like formats.
+// This is a TERMINAL operation - it consumes the entire stream.
+//
+// Example:
+//
+//	byPos := flow.Of("a", "b", "c").CollectIndexed() // map[0:a 1:b 2:c]
+func (f Flow[T, R]) CollectIndexed() map[int]T {
+	result := make(map[int]T)
+	i := 0
+	for k := range f.source {
+		result[i] = k
+		i++
+	}
+	return result
+}
+
+// ToIndexedSlice gathers all elements into a slice of position-element pairs.
+// The Key of each pair is the element's 0-based position in the stream.
+// This is a TERMINAL operation - it consumes the entire stream.
+//
+// Example:
+//
+//	flow.Of("a", "b").ToIndexedSlice() // [{0 a} {1 b}]
+func (f Flow[T, R]) ToIndexedSlice() []KeyValue[int, T] {
+	result := make([]KeyValue[int, T], 0, 16)
+	for k := range f.source {
+		result = append(result, KeyValue[int, T]{Key: len(result), Value: k})
+	}
+	return result
+}
+
+// ToSlicePointers returns pointers to the elements of the stream for in-place mutation.
+// For flows created directly by NewFlow (and Of, FromSlice, Values) the pointers
+// alias the original slice, so writes through them modify the caller's data.
+// Any other flow, including one derived via Filter, Take, etc., is collected
+// first and the pointers refer to that fresh copy instead.
+// This is a TERMINAL operation - it consumes the entire stream.
+//
+// Example:
+//
+//	people := []Person{{Name: "Alice", Age: 25}}
+//	for _, p := range flow.NewFlow(people).ToSlicePointers() {
+//	    p.Age++
+//	}
+//	// people[0].Age is now 26
+func (f Flow[T, R]) ToSlicePointers() []*T {
+	values := f.values
+	if !f.sliceBacked {
+		values = f.Collect()
+	}
+
+	pointers := make([]*T, len(values))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	return pointers
+}
+
+// CollectToBuilder appends each element, formatted by format, to sb.
+// Nothing is buffered besides the builder itself, which makes it suitable for
+// assembling large text outputs. The caller owns sb and reads the result from it.
+// This is a TERMINAL operation - it consumes the entire stream.
+//
+// Example:
+//
+//	var sb strings.Builder
+//	flow.Range(1, 4).CollectToBuilder(&sb, func(n int) string {
+//	    return fmt.Sprintf("line %d\n", n)
+//	})
+//	fmt.Print(sb.String())
+func (f Flow[T, R]) CollectToBuilder(sb *strings.Builder, format func(T) string) {
+	for k := range f.source {
+		sb.WriteString(format(k))
+	}
+}
+
 // CollectAny collects Flow[any, any] into []any
 func CollectAny(f Flow[any, any]) []any {
 	result := make([]any, 0, 16)
@@ -781,3 +1193,38 @@ func (f Flow[T, R]) ToChannel(bufferSize int) <-chan T {
 	}()
 	return ch
 }
+
+// ToDoneChannel sends all elements to a new channel like ToChannel and also returns
+// a done channel that is closed once production has finished. The data channel is
+// closed first, so by the time done is closed every element has been sent and any
+// still-buffered ones can be drained by ranging over the data channel.
+// This is a TERMINAL operation that runs in a goroutine.
+//
+// Example:
+//
+//	data, done := flow.NewFlow(jobs).ToDoneChannel(8)
+//	for {
+//	    select {
+//	    case job, ok := <-data:
+//	        if ok {
+//	            process(job)
+//	        }
+//	    case <-done:
+//	        for job := range data {
+//	            process(job)
+//	        }
+//	        return
+//	    }
+//	}
+func (f Flow[T, R]) ToDoneChannel(bufferSize int) (<-chan T, <-chan struct{}) {
+	ch := make(chan T, bufferSize)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(ch)
+		for k := range f.source {
+			ch <- k
+		}
+	}()
+	return ch, done
+}
@@ -0,0 +1,142 @@
+package flow
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+)
+
+// Heap is a binary min-heap ordered by a less function.
+// Create one from a flow with ToHeap. The zero value is not usable.
+type Heap[T any] struct {
+	items heapItems[T]
+}
+
+// heapItems adapts a slice and its ordering to container/heap.
+type heapItems[T any] struct {
+	values []T
+	less   func(a, b T) bool
+}
+
+func (h heapItems[T]) Len() int           { return len(h.values) }
+func (h heapItems[T]) Less(i, j int) bool { return h.less(h.values[i], h.values[j]) }
+func (h heapItems[T]) Swap(i, j int)      { h.values[i], h.values[j] = h.values[j], h.values[i] }
+
+func (h *heapItems[T]) Push(x any) {
+	h.values = append(h.values, x.(T))
+}
+
+func (h *heapItems[T]) Pop() any {
+	last := len(h.values) - 1
+	x := h.values[last]
+	var zero T
+	h.values[last] = zero
+	h.values = h.values[:last]
+	return x
+}
+
+// ToHeap collects all elements into a Heap ordered by less, so they can be
+// consumed in priority order afterwards. The smallest element according to
+// less is popped first; reverse the comparison for a max-heap.
+// Building the heap takes linear time after collection.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	tasks := flow.ToHeap(flow.NewFlow(pending), func(a, b Task) bool {
+//	    return a.Priority < b.Priority
+//	})
+//	for tasks.Len() > 0 {
+//	    task, _ := tasks.Pop()
+//	    run(task)
+//	}
+func ToHeap[T, R any](f Flow[T, R], less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{items: heapItems[T]{values: f.Collect(), less: less}}
+	heap.Init(&h.items)
+	return h
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return h.items.Len()
+}
+
+// Peek returns the smallest element without removing it.
+// The boolean is false if the heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if h.items.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items.values[0], true
+}
+
+// Pop removes and returns the smallest element.
+// The boolean is false if the heap is empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	if h.items.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(&h.items).(T), true
+}
+
+// Push adds an element to the heap.
+func (h *Heap[T]) Push(x T) {
+	heap.Push(&h.items, x)
+}
+
+// MergeSortedTopK performs a k-way merge of flows that are each sorted ascending
+// and returns the k smallest elements overall, in order.
+// Flows are pulled lazily, so only one pending element per flow is held in a heap
+// and no flow is advanced further than needed; merging stops after k elements,
+// which makes it safe to use with infinite sorted flows. Equal elements are taken
+// from earlier flows first. A k of zero or less returns nil.
+// This is a terminal operation that consumes at most k elements overall.
+//
+// Example:
+//
+//	// Best 10 hits across per-shard result streams, each sorted by rank
+//	top := flow.MergeSortedTopK(10, shard1, shard2, shard3)
+func MergeSortedTopK[T cmp.Ordered, R any](k int, flows ...Flow[T, R]) []T {
+	if k <= 0 {
+		return nil
+	}
+
+	type head struct {
+		value T
+		flow  int
+	}
+	heads := &Heap[head]{items: heapItems[head]{less: func(a, b head) bool {
+		if c := cmp.Compare(a.value, b.value); c != 0 {
+			return c < 0
+		}
+		return a.flow < b.flow
+	}}}
+
+	nexts := make([]func() (T, R, bool), len(flows))
+	for i, f := range flows {
+		next, stop := iter.Pull2(f.source)
+		defer stop()
+		nexts[i] = next
+		if v, _, ok := next(); ok {
+			heads.Push(head{value: v, flow: i})
+		}
+	}
+
+	result := make([]T, 0, k)
+	for len(result) < k {
+		h, ok := heads.Pop()
+		if !ok {
+			break
+		}
+		result = append(result, h.value)
+		if len(result) == k {
+			break // Do not advance the flow past the last needed element
+		}
+		if v, _, ok := nexts[h.flow](); ok {
+			heads.Push(head{value: v, flow: h.flow})
+		}
+	}
+	return result
+}
@@ -0,0 +1,168 @@
+package flow
+
+import (
+	"cmp"
+	"iter"
+)
+
+// MergeJoin performs an inner join of two flows that are both sorted by key.
+// It walks both flows in lockstep with iter.Pull, emitting a pair for every
+// left and right element sharing a key; keys present on only one side are skipped.
+// Only the run of right elements sharing the current key is buffered, so memory
+// stays bounded even for one-to-many matches.
+// Both inputs MUST be sorted ascending by their key; unsorted input silently
+// produces missing matches.
+//
+// Example:
+//
+//	orders := flow.NewFlow(ordersByCustomer)     // sorted by CustomerID
+//	customers := flow.NewFlow(customersByID)     // sorted by ID
+//	flow.MergeJoin(orders, customers,
+//	    func(o Order) int { return o.CustomerID },
+//	    func(c Customer) int { return c.ID },
+//	)
+func MergeJoin[T, U, R1, R2 any, K cmp.Ordered](left Flow[T, R1], right Flow[U, R2], lk func(T) K, rk func(U) K) Flow[Pair[T, U], Pair[T, U]] {
+	return Flow[Pair[T, U], Pair[T, U]]{
+		source: func(yield func(Pair[T, U], Pair[T, U]) bool) {
+			nextL, stopL := iter.Pull2(left.source)
+			defer stopL()
+			nextR, stopR := iter.Pull2(right.source)
+			defer stopR()
+
+			l, _, okL := nextL()
+			r, _, okR := nextR()
+			for okL && okR {
+				key := rk(r)
+				switch c := cmp.Compare(lk(l), key); {
+				case c < 0:
+					l, _, okL = nextL()
+				case c > 0:
+					r, _, okR = nextR()
+				default:
+					group := []U{r}
+					for {
+						r, _, okR = nextR()
+						if !okR || cmp.Compare(rk(r), key) != 0 {
+							break
+						}
+						group = append(group, r)
+					}
+					for okL && cmp.Compare(lk(l), key) == 0 {
+						for _, u := range group {
+							pair := Pair[T, U]{First: l, Second: u}
+							if !yield(pair, pair) {
+								return
+							}
+						}
+						l, _, okL = nextL()
+					}
+				}
+			}
+		},
+	}
+}
+
+// HashJoin performs an inner join of two unsorted flows.
+// The right flow is fully materialized into a multimap keyed by rk when the
+// result is consumed; the left flow is then streamed, emitting a pair for each
+// matching right element in right-stream order. Left elements without a match
+// are skipped. Put the smaller flow on the right to limit memory.
+//
+// Example:
+//
+//	flow.HashJoin(flow.NewFlow(orders), flow.NewFlow(customers),
+//	    func(o Order) int { return o.CustomerID },
+//	    func(c Customer) int { return c.ID },
+//	)
+func HashJoin[T, U, R1, R2 any, K comparable](left Flow[T, R1], right Flow[U, R2], lk func(T) K, rk func(U) K) Flow[Pair[T, U], Pair[T, U]] {
+	return Flow[Pair[T, U], Pair[T, U]]{
+		source: func(yield func(Pair[T, U], Pair[T, U]) bool) {
+			index := GroupBy(right, rk)
+			for l := range left.source {
+				for _, r := range index[lk(l)] {
+					pair := Pair[T, U]{First: l, Second: r}
+					if !yield(pair, pair) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// LeftJoin performs a left outer join of two unsorted flows.
+// Like HashJoin, the right flow is materialized into a multimap and the left flow
+// is streamed. Every left element is emitted at least once: paired with each
+// matching right element, or with a nil Second when nothing matches.
+//
+// Example:
+//
+//	flow.LeftJoin(flow.NewFlow(customers), flow.NewFlow(orders),
+//	    func(c Customer) int { return c.ID },
+//	    func(o Order) int { return o.CustomerID },
+//	).ForEach(func(p flow.Pair[Customer, *Order]) {
+//	    if p.Second == nil {
+//	        fmt.Println(p.First.Name, "has no orders")
+//	    }
+//	})
+func LeftJoin[T, U, R1, R2 any, K comparable](left Flow[T, R1], right Flow[U, R2], lk func(T) K, rk func(U) K) Flow[Pair[T, *U], Pair[T, *U]] {
+	return Flow[Pair[T, *U], Pair[T, *U]]{
+		source: func(yield func(Pair[T, *U], Pair[T, *U]) bool) {
+			index := GroupBy(right, rk)
+			for l := range left.source {
+				matches := index[lk(l)]
+				if len(matches) == 0 {
+					pair := Pair[T, *U]{First: l}
+					if !yield(pair, pair) {
+						return
+					}
+					continue
+				}
+				for i := range matches {
+					pair := Pair[T, *U]{First: l, Second: &matches[i]}
+					if !yield(pair, pair) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// ZipByKey pairs elements of two unsorted flows that share a key, one to one.
+// The second flow is buffered into a map keyed by k2 when the result is consumed,
+// then the first flow is streamed; put the smaller flow second to limit memory.
+// Each element is used in at most one pair: a first-flow element is matched with
+// the earliest unused second-flow element of the same key, and elements left
+// without a partner on either side are dropped. Use HashJoin for all pairings.
+//
+// Example:
+//
+//	// Align requests with their responses by ID
+//	flow.ZipByKey(flow.NewFlow(requests), flow.NewFlow(responses),
+//	    func(r Request) string { return r.ID },
+//	    func(r Response) string { return r.RequestID },
+//	)
+func ZipByKey[T, U, R1, R2 any, K comparable](f1 Flow[T, R1], f2 Flow[U, R2], k1 func(T) K, k2 func(U) K) Flow[Pair[T, U], Pair[T, U]] {
+	return Flow[Pair[T, U], Pair[T, U]]{
+		source: func(yield func(Pair[T, U], Pair[T, U]) bool) {
+			pending := GroupBy(f2, k2)
+			for t := range f1.source {
+				key := k1(t)
+				queue := pending[key]
+				if len(queue) == 0 {
+					continue
+				}
+				if len(queue) == 1 {
+					delete(pending, key)
+				} else {
+					pending[key] = queue[1:]
+				}
+				pair := Pair[T, U]{First: t, Second: queue[0]}
+				if !yield(pair, pair) {
+					return
+				}
+			}
+		},
+	}
+}
@@ -1,5 +1,15 @@
 package flow
 
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
 // MapTo transforms each element to a different type.
 // This is a lazy operation - the mapper is not called until the stream is consumed.
 // Since Go doesn't support method-level type parameters, this is a standalone function.
@@ -22,157 +32,281 @@ func MapTo[T, U, R any](f Flow[T, R], mapper func(T) U) Flow[U, U] {
 	}
 }
 
-// Distinct removes duplicate elements from the stream.
-// Requires the type to be comparable.
-// This is a lazy operation but requires memory to track seen elements.
+// MapIndexed transforms each element together with its 0-based position.
+// The index counts elements as they reach MapIndexed, so after an upstream Filter
+// it reflects the position in the filtered stream, not in the original source.
+// This is a lazy operation.
 //
 // Example:
 //
-//	unique := flow.Distinct(flow.NewFlow([]int{1, 2, 2, 3, 3, 3, 4}))
-func Distinct[T comparable, R any](f Flow[T, R]) Flow[T, R] {
-	return Flow[T, R]{
-		source: func(yield func(T, R) bool) {
-			seen := make(map[T]bool)
-			for k, v := range f.source {
-				if !seen[k] {
-					seen[k] = true
-					if !yield(k, v) {
-						return
-					}
+//	labels := flow.MapIndexed(flow.Of("a", "b", "c"), func(i int, s string) string {
+//	    return fmt.Sprintf("%d:%s", i, s)
+//	}) // Produces: "0:a", "1:b", "2:c"
+func MapIndexed[T, U, R any](f Flow[T, R], mapper func(index int, value T) U) Flow[U, U] {
+	return Flow[U, U]{
+		source: func(yield func(U, U) bool) {
+			i := 0
+			for k := range f.source {
+				res := mapper(i, k)
+				i++
+				if !yield(res, res) {
+					return
 				}
 			}
 		},
 	}
 }
 
-// FlatMap transforms each element to a Flow and flattens the results.
-// Useful for working with nested structures.
+// RoundTo rounds each element to the given number of decimal places.
+// Midpoints are rounded half away from zero, as with math.Round, so 2.5 becomes 3
+// and -2.5 becomes -3 (not half-to-even). A negative decimals rounds to tens,
+// hundreds and so on. Values that are not exactly representable in binary, such
+// as 2.675, may round down because their stored value lies just below the midpoint.
+// Values too large to have any digits beyond the requested precision, including
+// NaN and infinities, are yielded unchanged.
+// This is a lazy operation.
 //
 // Example:
 //
-//	words := flow.NewFlow([]string{"hello", "world"})
-//	letters := flow.FlatMap(words, func(word string) flow.Flow[rune] {
-//	    return flow.NewFlow([]rune(word))
-//	})
-func FlatMap[T, U, R1, R2 any](f Flow[T, R1], mapper func(T) Flow[U, R2]) Flow[U, R2] {
-	return Flow[U, R2]{
-		source: func(yield func(U, R2) bool) {
-			for k, _ := range f.source {
-				subFlow := mapper(k)
-				for subK, subV := range subFlow.source {
-					if !yield(subK, subV) {
-						return
+//	flow.RoundTo(flow.Of(3.14159, -2.71828), 2).Collect() // [3.14 -2.72]
+func RoundTo[R any](f Flow[float64, R], decimals int) Flow[float64, float64] {
+	scale := math.Pow10(decimals)
+	return Flow[float64, float64]{
+		source: func(yield func(float64, float64) bool) {
+			for k := range f.source {
+				res := k
+				if scaled := k * scale; math.Abs(scaled) < 1<<53 {
+					res = math.Round(scaled) / scale
+				}
+				if !yield(res, res) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// MapToMemo transforms each element like MapTo, caching mapper results by input value.
+// Repeated inputs reuse the cached result instead of calling the mapper again,
+// which pays off for expensive, pure mappers. The cache lives for one consumption
+// of the flow and is unbounded; use MapToMemoBounded to limit its size.
+//
+// Example:
+//
+//	profiles := flow.MapToMemo(flow.NewFlow(userIDs), fetchProfile)
+func MapToMemo[T comparable, U, R any](f Flow[T, R], mapper func(T) U) Flow[U, U] {
+	return MapToMemoBounded(f, mapper, 0)
+}
+
+// MapToMemoBounded is like MapToMemo but keeps at most maxEntries cached results.
+// When the cache is full, the oldest entry is evicted first.
+// A maxEntries of zero or less means the cache is unbounded.
+//
+// Example:
+//
+//	profiles := flow.MapToMemoBounded(flow.NewFlow(userIDs), fetchProfile, 1024)
+func MapToMemoBounded[T comparable, U, R any](f Flow[T, R], mapper func(T) U, maxEntries int) Flow[U, U] {
+	return Flow[U, U]{
+		source: func(yield func(U, U) bool) {
+			cache := make(map[T]U)
+			var order []T
+			for k := range f.source {
+				res, ok := cache[k]
+				if !ok {
+					res = mapper(k)
+					if maxEntries > 0 && len(cache) >= maxEntries {
+						delete(cache, order[0])
+						order = order[1:]
 					}
+					cache[k] = res
+					if maxEntries > 0 {
+						order = append(order, k)
+					}
+				}
+				if !yield(res, res) {
+					return
 				}
 			}
 		},
 	}
 }
 
-// Chunk groups elements into slices of specified size.
-// The last chunk may have fewer elements if the stream size is not divisible by the chunk size.
+// MapBatch transforms the stream in batches of 'size' elements and flattens the results.
+// The mapper receives each full batch (and the final partial batch, if any) and
+// may return a slice of any length. This is useful for calling vectorized or
+// batch-oriented APIs. The mapper may keep the batch slice it receives.
 //
 // Example:
 //
-//	chunks := flow.Chunk(flow.Range(1, 11), 3)
-//	// Produces: [1,2,3], [4,5,6], [7,8,9], [10]
-func Chunk[T, R any](f Flow[T, R], size int) Flow[[]T, []T] {
+//	normalized := flow.MapBatch(flow.NewFlow(samples), 256, func(batch []float64) []float64 {
+//	    return vectorNormalize(batch)
+//	})
+func MapBatch[T, U, R any](f Flow[T, R], size int, mapper func([]T) []U) Flow[U, U] {
 	if size <= 0 {
-		panic("chunk size must be positive")
+		panic("batch size must be positive")
 	}
 
-	return Flow[[]T, []T]{
-		source: func(yield func([]T, []T) bool) {
-			chunk := make([]T, 0, size)
-			for k, _ := range f.source {
-				chunk = append(chunk, k)
-				if len(chunk) == size {
-					chunkCopy := make([]T, len(chunk))
-					copy(chunkCopy, chunk)
-					if !yield(chunkCopy, chunkCopy) {
+	return Flow[U, U]{
+		source: func(yield func(U, U) bool) {
+			emit := func(batch []T) bool {
+				for _, res := range mapper(batch) {
+					if !yield(res, res) {
+						return false
+					}
+				}
+				return true
+			}
+
+			batch := make([]T, 0, size)
+			for k := range f.source {
+				batch = append(batch, k)
+				if len(batch) == size {
+					if !emit(batch) {
 						return
 					}
-					chunk = chunk[:0]
+					batch = make([]T, 0, size)
 				}
 			}
-			if len(chunk) > 0 {
-				yield(chunk, chunk)
+			if len(batch) > 0 {
+				emit(batch)
 			}
 		},
 	}
 }
 
-// Combine merges two flows into pairs.
-// The resulting flow ends when either input flow ends.
+// MapToBatched transforms elements through a batch mapper that returns exactly one
+// result per input, such as a bulk lookup against an external service.
+// Inputs are accumulated into batches of 'size' (the final batch may be smaller)
+// and the mapper is called once per batch; results are yielded in input order.
+// Unlike MapBatch, results must stay correlated with their inputs: MapToBatched
+// panics if the mapper returns a slice whose length differs from the batch.
 //
 // Example:
 //
-//	names := flow.NewFlow([]string{"Alice", "Bob"})
-//	ages := flow.NewFlow([]int{25, 30})
-//	pairs := flow.Combine(names, ages)
-//	// Produces: {First: "Alice", Second: 25}, {First: "Bob", Second: 30}
-func Combine[T, U, R1, R2 any](f1 Flow[T, R1], f2 Flow[U, R2]) Flow[Pair[T, U], Pair[T, U]] {
-	return Flow[Pair[T, U], Pair[T, U]]{
-		source: func(yield func(Pair[T, U], Pair[T, U]) bool) {
-			var vals1 []T
-			var vals2 []U
-
-			for k, _ := range f1.source {
-				vals1 = append(vals1, k)
-			}
-			for k, _ := range f2.source {
-				vals2 = append(vals2, k)
-			}
+//	users := flow.MapToBatched(flow.NewFlow(userIDs), 100, func(ids []string) []User {
+//	    return client.GetUsers(ids) // one user per ID, in the same order
+//	})
+func MapToBatched[T, U, R any](f Flow[T, R], size int, batchMapper func([]T) []U) Flow[U, U] {
+	return MapBatch(f, size, func(batch []T) []U {
+		results := batchMapper(batch)
+		if len(results) != len(batch) {
+			panic(fmt.Sprintf("batch mapper returned %d results for %d inputs", len(results), len(batch)))
+		}
+		return results
+	})
+}
 
-			minLen := min(len(vals2), len(vals1))
+// MapWithNext transforms each element with a one-element lookahead.
+// The mapper receives the current element and a pointer to the following one,
+// or nil for the last element. The lookahead is implemented with iter.Pull, so
+// the flow stays lazy and reads at most one element ahead.
+//
+// Example:
+//
+//	flow.MapWithNext(flow.Of("a", "b", "c"), func(cur string, next *string) string {
+//	    if next == nil {
+//	        return cur
+//	    }
+//	    return cur + ","
+//	}) // Produces: "a,", "b,", "c"
+func MapWithNext[T, U, R any](f Flow[T, R], mapper func(current T, next *T) U) Flow[U, U] {
+	return Flow[U, U]{
+		source: func(yield func(U, U) bool) {
+			pull, stop := iter.Pull2(f.source)
+			defer stop()
 
-			for i := range minLen {
-				pair := Pair[T, U]{First: vals1[i], Second: vals2[i]}
-				if !yield(pair, pair) {
+			current, _, ok := pull()
+			for ok {
+				next, _, hasNext := pull()
+				var nextPtr *T
+				if hasNext {
+					nextPtr = &next
+				}
+				res := mapper(current, nextPtr)
+				if !yield(res, res) {
 					return
 				}
+				current, ok = next, hasNext
 			}
 		},
 	}
 }
 
-// Pair represents a pair of values.
-// Used by the Combine function.
-type Pair[T, U any] struct {
-	First  T
-	Second U
+// MapToSwitch transforms each element with the mapper chosen by selector.
+// Every element is passed to mappers[selector(val)], which lets heterogeneous
+// elements be processed by different functions in a single pass.
+// Panics if the selector returns an index outside of mappers.
+//
+// Example:
+//
+//	const (
+//	    small = iota
+//	    large
+//	)
+//	labels := flow.MapToSwitch(flow.Of(5, 500, 50),
+//	    func(x int) int {
+//	        if x < 100 {
+//	            return small
+//	        }
+//	        return large
+//	    },
+//	    func(x int) string { return fmt.Sprintf("small %d", x) },
+//	    func(x int) string { return fmt.Sprintf("large %d", x) },
+//	)
+//	// Produces: "small 5", "large 500", "small 50"
+func MapToSwitch[T, U, R any](f Flow[T, R], selector func(T) int, mappers ...func(T) U) Flow[U, U] {
+	return Flow[U, U]{
+		source: func(yield func(U, U) bool) {
+			for k := range f.source {
+				i := selector(k)
+				if i < 0 || i >= len(mappers) {
+					panic(fmt.Sprintf("selector returned %d, but only %d mappers are available", i, len(mappers)))
+				}
+				res := mappers[i](k)
+				if !yield(res, res) {
+					return
+				}
+			}
+		},
+	}
 }
 
-// CombineWith merges two flows using a custom combiner function.
-// This provides more flexibility than Combine by allowing custom result types.
-// The resulting flow ends when either input flow ends.
+// MapWithWindowStat transforms each element together with the mean of the trailing
+// window of up to 'window' elements, which includes the current element.
+// Until 'window' elements have been seen, the mean covers all elements so far.
+// The mean is recomputed from the window on every element, so each step costs
+// O(window), and a NaN or infinity only affects the means of windows containing it.
 //
 // Example:
 //
-//	names := flow.NewFlow([]string{"Alice", "Bob"})
-//	ages := flow.NewFlow([]int{25, 30})
-//	people := flow.CombineWith(names, ages, func(name string, age int) string {
-//	    return fmt.Sprintf("%s is %d years old", name, age)
+//	// Flag readings more than 50% above the recent average
+//	flags := flow.MapWithWindowStat(flow.NewFlow(readings), 10, func(v float64, mean float64) bool {
+//	    return v > mean*1.5
 //	})
-//	// Produces: "Alice is 25 years old", "Bob is 30 years old"
-func CombineWith[T, U, V, R1, R2 any](f1 Flow[T, R1], f2 Flow[U, R2], combiner func(T, U) V) Flow[V, V] {
-	return Flow[V, V]{
-		source: func(yield func(V, V) bool) {
-			var vals1 []T
-			var vals2 []U
-
-			for k, _ := range f1.source {
-				vals1 = append(vals1, k)
-			}
-			for k, _ := range f2.source {
-				vals2 = append(vals2, k)
-			}
+func MapWithWindowStat[T Number, U, R any](f Flow[T, R], window int, mapper func(val T, windowMean float64) U) Flow[U, U] {
+	if window <= 0 {
+		panic("window size must be positive")
+	}
 
-			minLen := min(len(vals2), len(vals1))
+	return Flow[U, U]{
+		source: func(yield func(U, U) bool) {
+			recent := make([]float64, 0, window)
+			next := 0
+			for k := range f.source {
+				v := float64(k)
+				if len(recent) < window {
+					recent = append(recent, v)
+				} else {
+					recent[next] = v
+					next = (next + 1) % window
+				}
 
-			for i := range minLen {
-				result := combiner(vals1[i], vals2[i])
-				if !yield(result, result) {
+				sum := 0.0
+				for _, r := range recent {
+					sum += r
+				}
+				res := mapper(k, sum/float64(len(recent)))
+				if !yield(res, res) {
 					return
 				}
 			}
@@ -180,31 +314,20 @@ func CombineWith[T, U, V, R1, R2 any](f1 Flow[T, R1], f2 Flow[U, R2], combiner f
 	}
 }
 
-// Merge combines multiple flows into a single flow.
-// Unlike Combine, this concatenates flows sequentially rather than pairing elements.
-// Elements from all flows are yielded in the order they appear.
-// Can be called without arguments, in which case it returns an empty flow.
+// Distinct removes duplicate elements from the stream.
+// Requires the type to be comparable.
+// This is a lazy operation but requires memory to track seen elements.
 //
 // Example:
 //
-//	flow1 := flow.NewFlow([]int{1, 2, 3})
-//	flow2 := flow.NewFlow([]int{4, 5, 6})
-//	flow3 := flow.NewFlow([]int{7, 8, 9})
-//	merged := flow.Merge(flow1, flow2, flow3)
-//	// Produces: 1, 2, 3, 4, 5, 6, 7, 8, 9
-//
-//	// Can also chain with method:
-//	merged2 := flow1.Merge(flow2, flow3)
-//	// Produces: 1, 2, 3, 4, 5, 6, 7, 8, 9
-func Merge[T, R any](flows ...Flow[T, R]) Flow[T, R] {
-	if len(flows) == 0 {
-		return Flow[T, R]{source: func(yield func(T, R) bool) {}}
-	}
-
+//	unique := flow.Distinct(flow.NewFlow([]int{1, 2, 2, 3, 3, 3, 4}))
+func Distinct[T comparable, R any](f Flow[T, R]) Flow[T, R] {
 	return Flow[T, R]{
 		source: func(yield func(T, R) bool) {
-			for _, f := range flows {
-				for k, v := range f.source {
+			seen := make(map[T]bool)
+			for k, v := range f.source {
+				if !seen[k] {
+					seen[k] = true
 					if !yield(k, v) {
 						return
 					}
@@ -214,42 +337,55 @@ func Merge[T, R any](flows ...Flow[T, R]) Flow[T, R] {
 	}
 }
 
-// GroupBy groups elements by a key function.
-// Returns a map where keys are the result of the keyFunc and values are slices of elements.
-// This is a terminal operation that consumes the entire stream.
+// DistinctBy removes elements whose key, computed by keyFunc, has already been seen.
+// The first element for each key is kept and elements are yielded in arrival order.
+// Unlike Distinct, only the key needs to be comparable.
+// This is a lazy operation but requires memory to track seen keys.
 //
 // Example:
 //
-//	people := []Person{{Name: "Alice", Age: 25}, {Name: "Bob", Age: 30}, {Name: "Charlie", Age: 25}}
-//	byAge := flow.GroupBy(flow.NewFlow(people), func(p Person) int { return p.Age })
-//	// Result: map[25:[{Alice 25} {Charlie 25}] 30:[{Bob 30}]]
-func GroupBy[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) map[K][]T {
-	result := make(map[K][]T)
-	for k, _ := range f.source {
-		key := keyFunc(k)
-		result[key] = append(result[key], k)
+//	uniqueNames := flow.DistinctBy(flow.NewFlow(people), func(p Person) string { return p.Name })
+func DistinctBy[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			seen := make(map[K]struct{})
+			for k, v := range f.source {
+				key := keyFunc(k)
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
 	}
-	return result
 }
 
-// GroupByFlow is a lazy version of GroupBy that returns a Flow of groups.
-// Each group is represented as a KeyValue pair containing the key and slice of values.
-// This is useful when you want to process groups lazily.
+// TakeUntilRepeat yields elements until one repeats a previously yielded value.
+// The repeated value is not yielded and the flow stops there, which makes it a
+// safe guard against cycles and fixed points in generated sequences.
+// Memory grows with the number of distinct values yielded.
 //
 // Example:
 //
-//	people := []Person{{Name: "Alice", Age: 25}, {Name: "Bob", Age: 30}, {Name: "Charlie", Age: 25}}
-//	groups := flow.GroupByFlow(flow.NewFlow(people), func(p Person) int { return p.Age })
-//	groups.ForEach(func(kv KeyValue[int, []Person]) {
-//	    fmt.Printf("Age %d: %v\n", kv.Key, kv.Value)
+//	// Digits of 1/7 repeat after six steps
+//	remainders := flow.FromFunc(func(yield func(int, int) bool) {
+//	    for r := 1; yield(r, r); r = r * 10 % 7 {
+//	    }
 //	})
-func GroupByFlow[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) Flow[KeyValue[K, []T], KeyValue[K, []T]] {
-	return Flow[KeyValue[K, []T], KeyValue[K, []T]]{
-		source: func(yield func(KeyValue[K, []T], KeyValue[K, []T]) bool) {
-			groups := GroupBy(f, keyFunc)
-			for key, values := range groups {
-				kv := KeyValue[K, []T]{Key: key, Value: values}
-				if !yield(kv, kv) {
+//	flow.TakeUntilRepeat(remainders) // Stream of 1, 3, 2, 6, 4, 5
+func TakeUntilRepeat[T comparable, R any](f Flow[T, R]) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			seen := make(map[T]struct{})
+			for k, v := range f.source {
+				if _, ok := seen[k]; ok {
+					return
+				}
+				seen[k] = struct{}{}
+				if !yield(k, v) {
 					return
 				}
 			}
@@ -257,40 +393,1135 @@ func GroupByFlow[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) Flow[K
 	}
 }
 
-// KeyValue represents a key-value pair.
-// Used by GroupByFlow and other key-value operations.
-type KeyValue[K comparable, V any] struct {
-	Key   K
-	Value V
+// DistinctWindow removes duplicates within a sliding window of recently yielded elements.
+// An element is dropped only if it equals one of the last 'window' yielded elements,
+// so a value reappearing after it has left the window is emitted again.
+// Memory is bounded by the window size, which makes it suitable for streaming dedup.
+//
+// Example:
+//
+//	flow.DistinctWindow(flow.Of(1, 2, 1, 3, 4, 1), 2)
+//	// Produces: 1, 2, 3, 4, 1
+func DistinctWindow[T comparable, R any](f Flow[T, R], window int) Flow[T, R] {
+	if window <= 0 {
+		panic("distinct window size must be positive")
+	}
+
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			ring := make([]T, 0, window)
+			counts := make(map[T]int, window)
+			pos := 0
+			for k, v := range f.source {
+				if counts[k] > 0 {
+					continue
+				}
+				if len(ring) < window {
+					ring = append(ring, k)
+				} else {
+					evicted := ring[pos]
+					if counts[evicted]--; counts[evicted] == 0 {
+						delete(counts, evicted)
+					}
+					ring[pos] = k
+					pos = (pos + 1) % window
+				}
+				counts[k]++
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
 }
 
-// Partition splits a flow into two based on a predicate.
-// Returns two slices: elements that match the predicate and elements that don't.
-// This is a terminal operation that consumes the entire stream.
+// Changed yields the first element and afterwards only elements that differ from
+// the last emitted element according to equal. Comparing against the last emitted
+// element (not the previous input) means slow drift is still reported once it
+// accumulates, which suits tolerance-based comparators.
 //
 // Example:
 //
-//	evens, odds := flow.Partition(flow.Range(1, 11), func(x int) bool { return x%2 == 0 })
-//	// evens: [2, 4, 6, 8, 10]
-//	// odds: [1, 3, 5, 7, 9]
-func Partition[T, U any](f Flow[T, U], predicate func(T) bool) (matching []T, notMatching []T) {
-	for val := range f.source {
-		if predicate(val) {
-			matching = append(matching, val)
-		} else {
-			notMatching = append(notMatching, val)
-		}
+//	closeEnough := func(a, b float64) bool { return math.Abs(a-b) < 0.5 }
+//	flow.Changed(flow.Of(1.0, 1.2, 1.4, 1.6, 3.0), closeEnough)
+//	// Produces: 1.0, 1.6, 3.0
+func Changed[T, R any](f Flow[T, R], equal func(a, b T) bool) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			var last T
+			first := true
+			for k, v := range f.source {
+				if !first && equal(last, k) {
+					continue
+				}
+				last, first = k, false
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
 	}
-	return
 }
 
-// Window creates sliding windows of elements.
-// Each window contains 'size' elements, and windows overlap by 'size-step' elements.
-// If step equals size, windows don't overlap (tumbling windows).
+// FilterStateful filters elements with a predicate that can read and update shared state.
+// Each consumption of the flow starts from a fresh copy of initial, and a pointer
+// to that state is passed to the predicate for every element. This expresses
+// dedup, once-per-key and monotonic filters without hand-written closures.
 //
 // Example:
 //
-//	// Sliding window with size=3, step=1
+//	// Keep only values greater than everything seen before
+//	flow.FilterStateful(flow.Of(3, 1, 4, 1, 5), math.MinInt, func(max *int, x int) bool {
+//	    if x <= *max {
+//	        return false
+//	    }
+//	    *max = x
+//	    return true
+//	}) // Stream of 3, 4, 5
+func FilterStateful[T, R, S any](f Flow[T, R], initial S, predicate func(state *S, val T) bool) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			state := initial
+			for k, v := range f.source {
+				if predicate(&state, k) {
+					if !yield(k, v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// FilterIndexed keeps elements for which the predicate, given the element's
+// 0-based position, returns true. The index counts elements arriving at the
+// filter, including the ones it drops. This is a lazy operation.
+//
+// Example:
+//
+//	evenPositions := flow.FilterIndexed(flow.Of(10, 20, 30, 40), func(i, x int) bool {
+//	    return i%2 == 0
+//	}) // Produces: 10, 30
+func FilterIndexed[T, R any](f Flow[T, R], predicate func(index int, value T) bool) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			i := 0
+			for k, v := range f.source {
+				keep := predicate(i, k)
+				i++
+				if keep {
+					if !yield(k, v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// FilterE returns a Flow containing only elements that match a fallible predicate,
+// together with a function reporting the predicate error, if any.
+// When the predicate returns an error, the flow stops immediately instead of
+// treating the element as a non-match; call the returned function after
+// consumption to check whether the stream ended early. The error is reset
+// every time the flow is consumed.
+//
+// Example:
+//
+//	matches, errFn := flow.FilterE(flow.NewFlow(lines), func(line string) (bool, error) {
+//	    return regexp.MatchString(pattern, line)
+//	})
+//	result := matches.Collect()
+//	if err := errFn(); err != nil {
+//	    return err
+//	}
+func FilterE[T, R any](f Flow[T, R], predicate func(T) (bool, error)) (Flow[T, R], func() error) {
+	var err error
+	filtered := Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			err = nil
+			for k, v := range f.source {
+				ok, predErr := predicate(k)
+				if predErr != nil {
+					err = predErr
+					return
+				}
+				if ok {
+					if !yield(k, v) {
+						return
+					}
+				}
+			}
+		},
+	}
+	return filtered, func() error { return err }
+}
+
+// TakeEvery keeps the first element of every consecutive run of k elements,
+// i.e. the elements at positions 0, k, 2k, ... of the stream.
+// It is lazy and never stops early on its own; combine with Take to bound the output.
+//
+// Example:
+//
+//	flow.TakeEvery(flow.Range(0, 10), 3) // Stream of 0, 3, 6, 9
+func TakeEvery[T, R any](f Flow[T, R], k int) Flow[T, R] {
+	if k <= 0 {
+		panic("take every step must be positive")
+	}
+
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			i := 0
+			for key, v := range f.source {
+				if i%k == 0 {
+					if !yield(key, v) {
+						return
+					}
+				}
+				i++
+			}
+		},
+	}
+}
+
+// TakeWhileSum yields elements while their running sum stays within budget.
+// The flow stops before the first element that would push the sum above budget,
+// even if later elements would still fit.
+//
+// Example:
+//
+//	// Take jobs until a total cost of 10 is reached
+//	sizes := flow.TakeWhileSum(flow.Of(4, 3, 2, 5, 1), 10) // Produces: 4, 3, 2
+func TakeWhileSum[T Number, R any](f Flow[T, R], budget T) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			var sum T
+			for k, v := range f.source {
+				if sum+k > budget {
+					return
+				}
+				sum += k
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// FlatMap transforms each element to a Flow and flattens the results.
+// Useful for working with nested structures.
+//
+// Example:
+//
+//	words := flow.NewFlow([]string{"hello", "world"})
+//	letters := flow.FlatMap(words, func(word string) flow.Flow[rune] {
+//	    return flow.NewFlow([]rune(word))
+//	})
+func FlatMap[T, U, R1, R2 any](f Flow[T, R1], mapper func(T) Flow[U, R2]) Flow[U, R2] {
+	return Flow[U, R2]{
+		source: func(yield func(U, R2) bool) {
+			for k, _ := range f.source {
+				subFlow := mapper(k)
+				for subK, subV := range subFlow.source {
+					if !yield(subK, subV) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// Flatten lazily concatenates a flow of flows, yielding each inner flow in order.
+// It is FlatMap with an identity mapper. Stopping early stops both the outer
+// flow and the inner flow being iterated.
+//
+// Example:
+//
+//	flow.Flatten(flow.Of(flow.Of(1, 2), flow.Of(3, 4))).Collect() // [1 2 3 4]
+func Flatten[T, R1, R2 any](f Flow[Flow[T, R2], R1]) Flow[T, R2] {
+	return FlatMap(f, func(inner Flow[T, R2]) Flow[T, R2] { return inner })
+}
+
+// FlattenSlice lazily yields every element of every slice in order, undoing Chunk.
+// Empty slices contribute nothing.
+//
+// Example:
+//
+//	flow.FlattenSlice(flow.Chunk(flow.Range(0, 5), 2)).Collect() // [0 1 2 3 4]
+func FlattenSlice[T, R any](f Flow[[]T, R]) Flow[T, T] {
+	return Flow[T, T]{
+		source: func(yield func(T, T) bool) {
+			for slice := range f.source {
+				for _, v := range slice {
+					if !yield(v, v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// MapMany transforms each element into zero or more elements.
+// An element mapped to an empty slice disappears from the stream, and one mapped
+// to several elements expands in place. This is the slice-returning form of FlatMap.
+//
+// Example:
+//
+//	words := flow.MapMany(flow.Of("a b", "", "c"), strings.Fields)
+//	// Produces: "a", "b", "c"
+func MapMany[T, U, R any](f Flow[T, R], mapper func(T) []U) Flow[U, U] {
+	return Flow[U, U]{
+		source: func(yield func(U, U) bool) {
+			for k := range f.source {
+				for _, res := range mapper(k) {
+					if !yield(res, res) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// Chunk groups elements into slices of specified size.
+// The last chunk may have fewer elements if the stream size is not divisible by the chunk size.
+//
+// Example:
+//
+//	chunks := flow.Chunk(flow.Range(1, 11), 3)
+//	// Produces: [1,2,3], [4,5,6], [7,8,9], [10]
+func Chunk[T, R any](f Flow[T, R], size int) Flow[[]T, []T] {
+	if size <= 0 {
+		panic("chunk size must be positive")
+	}
+
+	return Flow[[]T, []T]{
+		source: func(yield func([]T, []T) bool) {
+			chunk := make([]T, 0, size)
+			for k, _ := range f.source {
+				chunk = append(chunk, k)
+				if len(chunk) == size {
+					chunkCopy := make([]T, len(chunk))
+					copy(chunkCopy, chunk)
+					if !yield(chunkCopy, chunkCopy) {
+						return
+					}
+					chunk = chunk[:0]
+				}
+			}
+			if len(chunk) > 0 {
+				yield(chunk, chunk)
+			}
+		},
+	}
+}
+
+// ChunkPadded groups elements into slices of specified size, padding the final chunk.
+// If the stream size is not divisible by size, the last chunk is filled up with pad
+// so that every chunk has exactly size elements.
+//
+// Example:
+//
+//	chunks := flow.ChunkPadded(flow.Range(1, 8), 3, 0)
+//	// Produces: [1,2,3], [4,5,6], [7,0,0]
+func ChunkPadded[T, R any](f Flow[T, R], size int, pad T) Flow[[]T, []T] {
+	chunks := Chunk(f, size)
+	return Flow[[]T, []T]{
+		source: func(yield func([]T, []T) bool) {
+			for chunk := range chunks.source {
+				for len(chunk) < size {
+					chunk = append(chunk, pad)
+				}
+				if !yield(chunk, chunk) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// ChunkByWeight groups consecutive elements into chunks whose total weight stays within maxWeight.
+// A new chunk is started whenever adding the next element would exceed maxWeight.
+// An element whose weight alone exceeds maxWeight is placed in a chunk of its own.
+//
+// Example:
+//
+//	// Pack messages into requests of at most 1 MiB
+//	requests := flow.ChunkByWeight(flow.NewFlow(messages), 1<<20, func(m []byte) int { return len(m) })
+func ChunkByWeight[T, R any](f Flow[T, R], maxWeight int, weightFunc func(T) int) Flow[[]T, []T] {
+	return Flow[[]T, []T]{
+		source: func(yield func([]T, []T) bool) {
+			var chunk []T
+			weight := 0
+			for k := range f.source {
+				w := weightFunc(k)
+				if len(chunk) > 0 && weight+w > maxWeight {
+					if !yield(chunk, chunk) {
+						return
+					}
+					chunk, weight = nil, 0
+				}
+				chunk = append(chunk, k)
+				weight += w
+			}
+			if len(chunk) > 0 {
+				yield(chunk, chunk)
+			}
+		},
+	}
+}
+
+// Combine merges two flows into pairs.
+// The resulting flow ends when either input flow ends.
+// Both flows are consumed lazily, so either of them may be infinite.
+//
+// Example:
+//
+//	names := flow.NewFlow([]string{"Alice", "Bob"})
+//	ages := flow.NewFlow([]int{25, 30})
+//	pairs := flow.Combine(names, ages)
+//	// Produces: {First: "Alice", Second: 25}, {First: "Bob", Second: 30}
+func Combine[T, U, R1, R2 any](f1 Flow[T, R1], f2 Flow[U, R2]) Flow[Pair[T, U], Pair[T, U]] {
+	return ZipInto(f1, f2, func(a T, b U) Pair[T, U] {
+		return Pair[T, U]{First: a, Second: b}
+	})
+}
+
+// Pair represents a pair of values.
+// Used by the Combine function.
+type Pair[T, U any] struct {
+	First  T
+	Second U
+}
+
+// CombineWith merges two flows using a custom combiner function.
+// This provides more flexibility than Combine by allowing custom result types.
+// The resulting flow ends when either input flow ends.
+// It is equivalent to ZipInto.
+//
+// Example:
+//
+//	names := flow.NewFlow([]string{"Alice", "Bob"})
+//	ages := flow.NewFlow([]int{25, 30})
+//	people := flow.CombineWith(names, ages, func(name string, age int) string {
+//	    return fmt.Sprintf("%s is %d years old", name, age)
+//	})
+//	// Produces: "Alice is 25 years old", "Bob is 30 years old"
+func CombineWith[T, U, V, R1, R2 any](f1 Flow[T, R1], f2 Flow[U, R2], combiner func(T, U) V) Flow[V, V] {
+	return ZipInto(f1, f2, combiner)
+}
+
+// ZipInto merges two flows element by element using a combine function.
+// Both flows are advanced in lockstep with iter.Pull, so nothing is buffered
+// and either flow may be infinite. The resulting flow ends when either input ends.
+//
+// Example:
+//
+//	indexes := flow.Infinite(func(i int) int { return i })
+//	names := flow.Of("Alice", "Bob")
+//	flow.ZipInto(indexes, names, func(i int, name string) string {
+//	    return fmt.Sprintf("%d. %s", i+1, name)
+//	})
+//	// Produces: "1. Alice", "2. Bob"
+func ZipInto[T, U, V, R1, R2 any](f1 Flow[T, R1], f2 Flow[U, R2], combine func(T, U) V) Flow[V, V] {
+	return Flow[V, V]{
+		source: func(yield func(V, V) bool) {
+			next1, stop1 := iter.Pull2(f1.source)
+			defer stop1()
+			next2, stop2 := iter.Pull2(f2.source)
+			defer stop2()
+
+			for {
+				a, _, ok := next1()
+				if !ok {
+					return
+				}
+				b, _, ok := next2()
+				if !ok {
+					return
+				}
+				result := combine(a, b)
+				if !yield(result, result) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// CombineLatest merges two flows by pairing the most recent value of each.
+// Both flows are consumed concurrently, and a new pair is emitted whenever either
+// flow produces a value, once both flows have produced at least once.
+// The resulting flow ends when both input flows end.
+// This is intended for channel-backed flows where values arrive over time.
+// If the consumer stops early, a goroutine blocked inside such a source exits
+// once the source produces or closes.
+//
+// Example:
+//
+//	prices := flow.FromChannel(priceCh)
+//	rates := flow.FromChannel(rateCh)
+//	flow.CombineLatest(prices, rates).ForEach(func(p flow.Pair[float64, float64]) {
+//	    fmt.Println(p.First * p.Second)
+//	})
+func CombineLatest[T, U, R1, R2 any](f1 Flow[T, R1], f2 Flow[U, R2]) Flow[Pair[T, U], Pair[T, U]] {
+	return Flow[Pair[T, U], Pair[T, U]]{
+		source: func(yield func(Pair[T, U], Pair[T, U]) bool) {
+			done := make(chan struct{})
+			defer close(done)
+
+			ch1 := make(chan T)
+			ch2 := make(chan U)
+			go pump(f1, ch1, done)
+			go pump(f2, ch2, done)
+
+			var latest Pair[T, U]
+			has1, has2 := false, false
+			for ch1 != nil || ch2 != nil {
+				select {
+				case v, ok := <-ch1:
+					if !ok {
+						ch1 = nil
+						continue
+					}
+					latest.First, has1 = v, true
+				case v, ok := <-ch2:
+					if !ok {
+						ch2 = nil
+						continue
+					}
+					latest.Second, has2 = v, true
+				}
+				if has1 && has2 {
+					if !yield(latest, latest) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// WithFirst pairs every element with the first element of the flow.
+// Each pair holds the element as First and the head of the flow as Second,
+// so the first element is paired with itself.
+// The head is captured with iter.Pull, keeping the rest of the flow lazy.
+//
+// Example:
+//
+//	flow.MapTo(flow.WithFirst(flow.Of(2.0, 4.0, 8.0)), func(p flow.Pair[float64, float64]) float64 {
+//	    return p.First / p.Second
+//	}) // Produces: 1, 2, 4
+func WithFirst[T, R any](f Flow[T, R]) Flow[Pair[T, T], Pair[T, T]] {
+	return Flow[Pair[T, T], Pair[T, T]]{
+		source: func(yield func(Pair[T, T], Pair[T, T]) bool) {
+			next, stop := iter.Pull2(f.source)
+			defer stop()
+
+			first, _, ok := next()
+			if !ok {
+				return
+			}
+			for k := first; ok; k, _, ok = next() {
+				pair := Pair[T, T]{First: k, Second: first}
+				if !yield(pair, pair) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// pump forwards every element of f to out until f ends or done is closed.
+// The out channel is closed when pumping stops.
+func pump[T, R any](f Flow[T, R], out chan<- T, done <-chan struct{}) {
+	defer close(out)
+	for k := range f.source {
+		select {
+		case out <- k:
+		case <-done:
+			return
+		}
+	}
+}
+
+// asPairs exposes both values of every yielded element as a single Pair,
+// so that flows can be forwarded through channels without losing R.
+func asPairs[T, R any](f Flow[T, R]) Flow[Pair[T, R], Pair[T, R]] {
+	return Flow[Pair[T, R], Pair[T, R]]{
+		source: func(yield func(Pair[T, R], Pair[T, R]) bool) {
+			for k, v := range f.source {
+				pair := Pair[T, R]{First: k, Second: v}
+				if !yield(pair, pair) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// Merge combines multiple flows into a single flow.
+// Unlike Combine, this concatenates flows sequentially rather than pairing elements.
+// Elements from all flows are yielded in the order they appear.
+// Can be called without arguments, in which case it returns an empty flow.
+//
+// Example:
+//
+//	flow1 := flow.NewFlow([]int{1, 2, 3})
+//	flow2 := flow.NewFlow([]int{4, 5, 6})
+//	flow3 := flow.NewFlow([]int{7, 8, 9})
+//	merged := flow.Merge(flow1, flow2, flow3)
+//	// Produces: 1, 2, 3, 4, 5, 6, 7, 8, 9
+//
+//	// Can also chain with method:
+//	merged2 := flow1.Merge(flow2, flow3)
+//	// Produces: 1, 2, 3, 4, 5, 6, 7, 8, 9
+func Merge[T, R any](flows ...Flow[T, R]) Flow[T, R] {
+	if len(flows) == 0 {
+		return Flow[T, R]{source: func(yield func(T, R) bool) {}}
+	}
+
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			for _, f := range flows {
+				for k, v := range f.source {
+					if !yield(k, v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// GroupBy groups elements by a key function.
+// Returns a map where keys are the result of the keyFunc and values are slices of elements.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	people := []Person{{Name: "Alice", Age: 25}, {Name: "Bob", Age: 30}, {Name: "Charlie", Age: 25}}
+//	byAge := flow.GroupBy(flow.NewFlow(people), func(p Person) int { return p.Age })
+//	// Result: map[25:[{Alice 25} {Charlie 25}] 30:[{Bob 30}]]
+func GroupBy[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) map[K][]T {
+	result := make(map[K][]T)
+	GroupByInto(f, keyFunc, result)
+	return result
+}
+
+// GroupByInto groups elements by a key function into a caller-provided map.
+// The dst map is mutated: elements are appended to any slices already present,
+// which allows reusing one map across iterations or accumulating several flows.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	byAge := make(map[int][]Person)
+//	flow.GroupByInto(flow.NewFlow(team1), func(p Person) int { return p.Age }, byAge)
+//	flow.GroupByInto(flow.NewFlow(team2), func(p Person) int { return p.Age }, byAge)
+func GroupByInto[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K, dst map[K][]T) {
+	for k := range f.source {
+		key := keyFunc(k)
+		dst[key] = append(dst[key], k)
+	}
+}
+
+// GroupByNested groups elements by a primary key and then by a secondary key in one pass.
+// Elements keep their stream order within each innermost group.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	byDeptRole := flow.GroupByNested(flow.NewFlow(employees),
+//	    func(e Employee) string { return e.Department },
+//	    func(e Employee) string { return e.Role },
+//	)
+//	engineers := byDeptRole["R&D"]["Engineer"]
+func GroupByNested[T, R any, K1, K2 comparable](f Flow[T, R], k1 func(T) K1, k2 func(T) K2) map[K1]map[K2][]T {
+	result := make(map[K1]map[K2][]T)
+	for k := range f.source {
+		primary := k1(k)
+		inner, ok := result[primary]
+		if !ok {
+			inner = make(map[K2][]T)
+			result[primary] = inner
+		}
+		secondary := k2(k)
+		inner[secondary] = append(inner[secondary], k)
+	}
+	return result
+}
+
+// DistinctValuesBy returns the distinct keys of the stream in order of first appearance.
+// Handy for discovering the categorical values of a field.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	statuses := flow.DistinctValuesBy(flow.NewFlow(orders), func(o Order) string { return o.Status })
+//	// e.g. ["pending", "shipped", "cancelled"]
+func DistinctValuesBy[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) []K {
+	seen := make(map[K]struct{})
+	keys := make([]K, 0, 16)
+	for k := range f.source {
+		key := keyFunc(k)
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// DistinctByMax keeps, among elements sharing a key, the one with the largest value.
+// Results are ordered by the first appearance of each key. When several elements
+// share the maximum value, the earliest one is kept.
+// This models "latest version per ID" deduplication.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	latest := flow.DistinctByMax(flow.NewFlow(records),
+//	    func(r Record) string { return r.ID },
+//	    func(r Record) int { return r.Version },
+//	)
+func DistinctByMax[T, R any, K comparable, V cmp.Ordered](f Flow[T, R], keyFunc func(T) K, valueFunc func(T) V) []T {
+	positions := make(map[K]int)
+	result := make([]T, 0, 16)
+	values := make([]V, 0, 16)
+	for k := range f.source {
+		key, value := keyFunc(k), valueFunc(k)
+		i, ok := positions[key]
+		if !ok {
+			positions[key] = len(result)
+			result = append(result, k)
+			values = append(values, value)
+			continue
+		}
+		if cmp.Less(values[i], value) {
+			result[i] = k
+			values[i] = value
+		}
+	}
+	return result
+}
+
+// GroupByFlow is a lazy version of GroupBy that returns a Flow of groups.
+// Each group is represented as a KeyValue pair containing the key and slice of values.
+// This is useful when you want to process groups lazily.
+//
+// Example:
+//
+//	people := []Person{{Name: "Alice", Age: 25}, {Name: "Bob", Age: 30}, {Name: "Charlie", Age: 25}}
+//	groups := flow.GroupByFlow(flow.NewFlow(people), func(p Person) int { return p.Age })
+//	groups.ForEach(func(kv KeyValue[int, []Person]) {
+//	    fmt.Printf("Age %d: %v\n", kv.Key, kv.Value)
+//	})
+func GroupByFlow[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) Flow[KeyValue[K, []T], KeyValue[K, []T]] {
+	return Flow[KeyValue[K, []T], KeyValue[K, []T]]{
+		source: func(yield func(KeyValue[K, []T], KeyValue[K, []T]) bool) {
+			groups := GroupBy(f, keyFunc)
+			for key, values := range groups {
+				kv := KeyValue[K, []T]{Key: key, Value: values}
+				if !yield(kv, kv) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// StreamingGroupBy groups consecutive elements that share a key, emitting each
+// group as soon as the key changes. Unlike GroupByFlow it never buffers more than
+// the current group, so it works on large and infinite flows.
+// The input must be key-contiguous (e.g. sorted by key): if a key reappears after
+// a different key, it starts a new, separate group.
+//
+// Example:
+//
+//	sorted := flow.NewFlow([]Person{{"Alice", 25}, {"Charlie", 25}, {"Bob", 30}})
+//	flow.StreamingGroupBy(sorted, func(p Person) int { return p.Age })
+//	// Produces: {25 [{Alice 25} {Charlie 25}]}, {30 [{Bob 30}]}
+func StreamingGroupBy[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) Flow[KeyValue[K, []T], KeyValue[K, []T]] {
+	return Flow[KeyValue[K, []T], KeyValue[K, []T]]{
+		source: func(yield func(KeyValue[K, []T], KeyValue[K, []T]) bool) {
+			var current KeyValue[K, []T]
+			for k := range f.source {
+				key := keyFunc(k)
+				if len(current.Value) > 0 && key != current.Key {
+					if !yield(current, current) {
+						return
+					}
+					current = KeyValue[K, []T]{}
+				}
+				current.Key = key
+				current.Value = append(current.Value, k)
+			}
+			if len(current.Value) > 0 {
+				yield(current, current)
+			}
+		},
+	}
+}
+
+// GroupByRunning emits a snapshot of an element's group every time the element joins it.
+// Each emission holds the group key and a copy of all members collected for that
+// key so far, so earlier snapshots are never modified by later elements.
+// Memory grows with the total number of elements, as every group is retained.
+//
+// Example:
+//
+//	flow.GroupByRunning(flow.Of("a1", "b1", "a2"), func(s string) byte { return s[0] })
+//	// Produces: {a [a1]}, {b [b1]}, {a [a1 a2]}
+func GroupByRunning[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) Flow[KeyValue[K, []T], KeyValue[K, []T]] {
+	return Flow[KeyValue[K, []T], KeyValue[K, []T]]{
+		source: func(yield func(KeyValue[K, []T], KeyValue[K, []T]) bool) {
+			groups := make(map[K][]T)
+			for k := range f.source {
+				key := keyFunc(k)
+				groups[key] = append(groups[key], k)
+				kv := KeyValue[K, []T]{Key: key, Value: slices.Clone(groups[key])}
+				if !yield(kv, kv) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// KeyValue represents a key-value pair.
+// Used by GroupByFlow and other key-value operations.
+type KeyValue[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Partition splits a flow into two based on a predicate.
+// Returns two slices: elements that match the predicate and elements that don't.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	evens, odds := flow.Partition(flow.Range(1, 11), func(x int) bool { return x%2 == 0 })
+//	// evens: [2, 4, 6, 8, 10]
+//	// odds: [1, 3, 5, 7, 9]
+func Partition[T, U any](f Flow[T, U], predicate func(T) bool) (matching []T, notMatching []T) {
+	for val := range f.source {
+		if predicate(val) {
+			matching = append(matching, val)
+		} else {
+			notMatching = append(notMatching, val)
+		}
+	}
+	return
+}
+
+// HashPartition distributes elements into buckets slices by hashFunc(val) % buckets.
+// Placement depends only on the hash, so the same element always lands in the
+// same bucket, and each bucket keeps the stream order of its elements.
+// Panics if buckets is not positive.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	shards := flow.HashPartition(flow.NewFlow(users), 4, func(u User) uint64 {
+//	    h := fnv.New64a()
+//	    h.Write([]byte(u.ID))
+//	    return h.Sum64()
+//	})
+//	// shards[i] holds the users for shard i
+func HashPartition[T, U any](f Flow[T, U], buckets int, hashFunc func(T) uint64) [][]T {
+	if buckets <= 0 {
+		panic("bucket count must be positive")
+	}
+
+	result := make([][]T, buckets)
+	for val := range f.source {
+		i := hashFunc(val) % uint64(buckets)
+		result[i] = append(result[i], val)
+	}
+	return result
+}
+
+// GroupByIndex assigns the element at 0-based position i to group i % groups,
+// dealing elements round-robin into groups slices. Each group keeps stream order.
+// Useful for deterministic fold or validation splits.
+// Panics if groups is not positive.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	folds := flow.GroupByIndex(flow.NewFlow(samples), 5)
+//	// folds[i] holds samples i, i+5, i+10, ...
+func GroupByIndex[T, U any](f Flow[T, U], groups int) [][]T {
+	if groups <= 0 {
+		panic("group count must be positive")
+	}
+
+	result := make([][]T, groups)
+	i := 0
+	for val := range f.source {
+		result[i] = append(result[i], val)
+		i = (i + 1) % groups
+	}
+	return result
+}
+
+// BucketBy distributes elements into len(boundaries)+1 ordered buckets by valueFunc(val).
+// Boundaries must be sorted in ascending order. Bucket 0 holds values below
+// boundaries[0], bucket i holds values in [boundaries[i-1], boundaries[i]), and the
+// last bucket holds values at or above the last boundary.
+// Elements whose value is NaN belong to no bucket and are dropped.
+// Panics if boundaries are not sorted or contain NaN.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	buckets := flow.BucketBy(flow.NewFlow(latencies), []float64{10, 100}, func(ms float64) float64 { return ms })
+//	// buckets[0]: under 10ms, buckets[1]: 10ms to 100ms, buckets[2]: 100ms and above
+func BucketBy[T, U any](f Flow[T, U], boundaries []float64, valueFunc func(T) float64) [][]T {
+	if !slices.IsSorted(boundaries) || slices.ContainsFunc(boundaries, math.IsNaN) {
+		panic("bucket boundaries must be sorted and not NaN")
+	}
+
+	result := make([][]T, len(boundaries)+1)
+	for val := range f.source {
+		v := valueFunc(val)
+		if math.IsNaN(v) {
+			continue
+		}
+		i := sort.Search(len(boundaries), func(j int) bool { return boundaries[j] > v })
+		result[i] = append(result[i], val)
+	}
+	return result
+}
+
+// ExplodeGroups flattens groups back into individual key-value pairs.
+// Each value of a group's slice is yielded together with the group key, lazily and
+// in slice order. It is the inverse of GroupByFlow, up to the order of groups.
+//
+// Example:
+//
+//	groups := flow.GroupByFlow(flow.NewFlow(people), func(p Person) int { return p.Age })
+//	flow.ExplodeGroups(groups).ForEach(func(kv flow.KeyValue[int, Person]) {
+//	    fmt.Printf("%d: %s\n", kv.Key, kv.Value.Name)
+//	})
+func ExplodeGroups[K comparable, V, R any](f Flow[KeyValue[K, []V], R]) Flow[KeyValue[K, V], KeyValue[K, V]] {
+	return Flow[KeyValue[K, V], KeyValue[K, V]]{
+		source: func(yield func(KeyValue[K, V], KeyValue[K, V]) bool) {
+			for group := range f.source {
+				for _, v := range group.Value {
+					kv := KeyValue[K, V]{Key: group.Key, Value: v}
+					if !yield(kv, kv) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// WithKey lazily pairs each element with the key computed by keyFunc.
+// It is a lighter alternative to grouping when only the key needs to be attached,
+// for example to produce flat tabular output. Element order is preserved.
+//
+// Example:
+//
+//	flow.WithKey(flow.NewFlow(people), func(p Person) string { return p.Department }).
+//	    ForEach(func(kv flow.KeyValue[string, Person]) {
+//	        fmt.Printf("%s\t%s\n", kv.Key, kv.Value.Name)
+//	    })
+func WithKey[T, R any, K comparable](f Flow[T, R], keyFunc func(T) K) Flow[KeyValue[K, T], KeyValue[K, T]] {
+	return Flow[KeyValue[K, T], KeyValue[K, T]]{
+		source: func(yield func(KeyValue[K, T], KeyValue[K, T]) bool) {
+			for k := range f.source {
+				kv := KeyValue[K, T]{Key: keyFunc(k), Value: k}
+				if !yield(kv, kv) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// PartitionMap routes each element into one of two typed slices, transforming it on the way.
+// The router returns both candidate values and isLeft; only the value for the
+// chosen side is kept. This is the Either-routing counterpart of Partition.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	numbers, words := flow.PartitionMap(flow.Of("1", "two", "3"), func(s string) (int, string, bool) {
+//	    n, err := strconv.Atoi(s)
+//	    return n, s, err == nil
+//	})
+//	// numbers: [1, 3]
+//	// words: ["two"]
+func PartitionMap[T, U, L, R any](f Flow[T, U], router func(T) (left L, right R, isLeft bool)) ([]L, []R) {
+	var lefts []L
+	var rights []R
+	for k := range f.source {
+		left, right, isLeft := router(k)
+		if isLeft {
+			lefts = append(lefts, left)
+		} else {
+			rights = append(rights, right)
+		}
+	}
+	return lefts, rights
+}
+
+// ReduceSegments reduces consecutive runs of elements into one value per segment.
+// Each element is folded into the current accumulator; when boundary returns true
+// for an element, the accumulator (including that element) is emitted and a fresh
+// one is created with initial. A trailing segment without a boundary is emitted
+// when the stream ends.
+//
+// Example:
+//
+//	// Per-session totals where a negative value marks the end of a session
+//	totals := flow.ReduceSegments(flow.Of(1, 2, -1, 5, -1, 3),
+//	    func() int { return 0 },
+//	    func(acc, x int) int { return acc + x },
+//	    func(x int) bool { return x < 0 },
+//	)
+//	// Produces: 2, 4, 3
+func ReduceSegments[T, A, R any](f Flow[T, R], initial func() A, acc func(A, T) A, boundary func(T) bool) Flow[A, A] {
+	return Flow[A, A]{
+		source: func(yield func(A, A) bool) {
+			current := initial()
+			pending := false
+			for k := range f.source {
+				current = acc(current, k)
+				pending = true
+				if boundary(k) {
+					if !yield(current, current) {
+						return
+					}
+					current = initial()
+					pending = false
+				}
+			}
+			if pending {
+				yield(current, current)
+			}
+		},
+	}
+}
+
+// Sorted yields the elements of the stream in ascending order.
+// This is a buffering operation: when the result is consumed, the whole stream is
+// collected and sorted with slices.Sort before the first element is yielded.
+// It is therefore not suitable for infinite flows. The source is never modified.
+//
+// Example:
+//
+//	flow.Sorted(flow.Of(3, 1, 2)).Collect() // [1 2 3]
+func Sorted[T cmp.Ordered, R any](f Flow[T, R]) Flow[T, T] {
+	return Flow[T, T]{
+		source: func(yield func(T, T) bool) {
+			values := f.Collect()
+			slices.Sort(values)
+			for _, v := range values {
+				if !yield(v, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// SortedFunc yields the elements of the stream ordered by the comparison function cmp,
+// which returns a negative number when a < b, zero when equal and a positive number
+// when a > b. The sort is stable, so equal elements keep their stream order.
+// Like Sorted, it buffers the whole stream and is not suitable for infinite flows.
+//
+// Example:
+//
+//	byAge := flow.SortedFunc(flow.NewFlow(people), func(a, b Person) int {
+//	    return cmp.Compare(a.Age, b.Age)
+//	})
+func SortedFunc[T, R any](f Flow[T, R], cmp func(a, b T) int) Flow[T, T] {
+	return Flow[T, T]{
+		source: func(yield func(T, T) bool) {
+			values := f.Collect()
+			slices.SortStableFunc(values, cmp)
+			for _, v := range values {
+				if !yield(v, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// CompactSorted sorts elements by key and drops elements whose key repeats,
+// keeping the last occurrence in stream order for each key.
+// This fuses the sort-then-dedupe step common when compacting logs or time series.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	events := flow.CompactSorted(flow.NewFlow(readings), func(r Reading) int64 { return r.Timestamp })
+//	// One reading per timestamp, ordered by timestamp, later readings win
+func CompactSorted[T, R any, K cmp.Ordered](f Flow[T, R], keyFunc func(T) K) []T {
+	entries := make([]KeyValue[K, T], 0, 16)
+	for k := range f.source {
+		entries = append(entries, KeyValue[K, T]{Key: keyFunc(k), Value: k})
+	}
+
+	slices.SortStableFunc(entries, func(a, b KeyValue[K, T]) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+
+	result := make([]T, 0, len(entries))
+	for i, entry := range entries {
+		if i+1 < len(entries) && cmp.Compare(entries[i+1].Key, entry.Key) == 0 {
+			continue
+		}
+		result = append(result, entry.Value)
+	}
+	return result
+}
+
+// CollectString concatenates a flow of runes into a string.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	upper := flow.CollectString(flow.MapTo(flow.FromRunes("hello"), unicode.ToUpper)) // "HELLO"
+func CollectString[R any](f Flow[rune, R]) string {
+	var sb strings.Builder
+	for r := range f.source {
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// CollectStringBytes concatenates a flow of bytes into a string.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	s := flow.CollectStringBytes(flow.NewFlow([]byte("hi"))) // "hi"
+func CollectStringBytes[R any](f Flow[byte, R]) string {
+	var sb strings.Builder
+	for b := range f.source {
+		sb.WriteByte(b)
+	}
+	return sb.String()
+}
+
+// Window creates sliding windows of elements.
+// Each window contains 'size' elements, and windows overlap by 'size-step' elements.
+// If step equals size, windows don't overlap (tumbling windows).
+//
+// Example:
+//
+//	// Sliding window with size=3, step=1
 //	windows := flow.Window(flow.Range(1, 6), 3, 1)
 //	// Produces: [1,2,3], [2,3,4], [3,4,5]
 //
@@ -329,3 +1560,190 @@ func Window[T, U any](f Flow[T, U], size, step int) Flow[[]T, U] {
 		},
 	}
 }
+
+// WindowCounts yields the number of elements in each tumbling window of 'size' elements.
+// The last window may be smaller if the stream size is not divisible by size.
+// It is cheaper than Window or Chunk followed by a count, since no windows are allocated.
+//
+// Example:
+//
+//	flow.WindowCounts(flow.Range(0, 7), 3) // Produces: 3, 3, 1
+func WindowCounts[T, R any](f Flow[T, R], size int) Flow[int, int] {
+	if size <= 0 {
+		panic("window size must be positive")
+	}
+
+	return Flow[int, int]{
+		source: func(yield func(int, int) bool) {
+			count := 0
+			for range f.source {
+				count++
+				if count == size {
+					if !yield(count, count) {
+						return
+					}
+					count = 0
+				}
+			}
+			if count > 0 {
+				yield(count, count)
+			}
+		},
+	}
+}
+
+// WindowByTrigger groups consecutive elements into windows that close on a trigger.
+// Before each element is added, shouldClose receives the current window and the
+// incoming element; if it returns true, the current window is emitted and the
+// element starts a new one. shouldClose is never called with an empty window, so
+// no empty windows are produced. The final window is flushed when the stream ends.
+//
+// Example:
+//
+//	// Split log lines into records that each start with a "BEGIN" marker
+//	records := flow.WindowByTrigger(flow.NewFlow(lines), func(current []string, next string) bool {
+//	    return next == "BEGIN"
+//	})
+func WindowByTrigger[T, R any](f Flow[T, R], shouldClose func(current []T, next T) bool) Flow[[]T, []T] {
+	return Flow[[]T, []T]{
+		source: func(yield func([]T, []T) bool) {
+			var window []T
+			for k := range f.source {
+				if len(window) > 0 && shouldClose(window, k) {
+					if !yield(window, window) {
+						return
+					}
+					window = nil
+				}
+				window = append(window, k)
+			}
+			if len(window) > 0 {
+				yield(window, window)
+			}
+		},
+	}
+}
+
+// RepeatEach yields every element n consecutive times.
+// A non-positive n yields nothing. This is a lazy operation.
+//
+// Example:
+//
+//	flow.RepeatEach(flow.Of("a", "b"), 3) // Stream of a, a, a, b, b, b
+func RepeatEach[T, R any](f Flow[T, R], n int) Flow[T, R] {
+	return RepeatEachBy(f, func(T) int { return n })
+}
+
+// RepeatEachBy yields every element as many consecutive times as countFunc returns for it.
+// Elements with a count of zero or less are dropped. This is a lazy operation,
+// useful for expanding aggregated rows back into individual records.
+//
+// Example:
+//
+//	type Stock struct{ Item string; Qty int }
+//	flow.RepeatEachBy(flow.Of(Stock{"apple", 2}, Stock{"pear", 0}), func(s Stock) int { return s.Qty })
+//	// Produces: {apple 2}, {apple 2}
+func RepeatEachBy[T, R any](f Flow[T, R], countFunc func(T) int) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			for k, v := range f.source {
+				for range countFunc(k) {
+					if !yield(k, v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// Scan yields the running accumulator after each element, like a prefix sum.
+// The initial value itself is not yielded, so the output has exactly one element
+// per input element. This is a lazy operation and works with infinite flows.
+//
+// Example:
+//
+//	flow.Scan(flow.Range(1, 5), 0, func(acc, x int) int { return acc + x })
+//	// Produces: 1, 3, 6, 10
+func Scan[T, R, A any](f Flow[T, R], initial A, acc func(A, T) A) Flow[A, A] {
+	return Flow[A, A]{
+		source: func(yield func(A, A) bool) {
+			current := initial
+			for k := range f.source {
+				current = acc(current, k)
+				if !yield(current, current) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// ScanWhile yields the running accumulator after each element until acc signals a stop.
+// The acc function returns the new accumulator and whether to continue; when it
+// returns false, that final accumulator is still yielded and the flow ends.
+// The initial value itself is not yielded.
+//
+// Example:
+//
+//	// Running total that stops once it reaches 10
+//	flow.ScanWhile(flow.Range(1, 100), 0, func(acc, x int) (int, bool) {
+//	    acc += x
+//	    return acc, acc < 10
+//	})
+//	// Produces: 1, 3, 6, 10
+func ScanWhile[T, R, A any](f Flow[T, R], initial A, acc func(A, T) (A, bool)) Flow[A, A] {
+	return Flow[A, A]{
+		source: func(yield func(A, A) bool) {
+			current := initial
+			for k := range f.source {
+				var ok bool
+				current, ok = acc(current, k)
+				if !yield(current, current) || !ok {
+					return
+				}
+			}
+		},
+	}
+}
+
+// Interval represents a closed range of integers from Start to End.
+// Used by MergeIntervals.
+type Interval struct {
+	Start int
+	End   int
+}
+
+// MergeIntervals merges overlapping or adjacent intervals of a flow sorted by Start.
+// Two consecutive intervals are merged when the second starts no later than one past
+// the end of the first, so {1 3} and {4 5} become {1 5}. Merging is lazy: each merged interval is yielded as soon as the
+// next non-overlapping interval arrives. The input MUST be sorted by Start.
+//
+// Example:
+//
+//	flow.MergeIntervals(flow.Of(
+//	    flow.Interval{Start: 1, End: 3},
+//	    flow.Interval{Start: 2, End: 5},
+//	    flow.Interval{Start: 7, End: 9},
+//	)) // Produces: {1 5}, {7 9}
+func MergeIntervals[R any](f Flow[Interval, R]) Flow[Interval, Interval] {
+	return Flow[Interval, Interval]{
+		source: func(yield func(Interval, Interval) bool) {
+			var current Interval
+			started := false
+			for iv := range f.source {
+				if started && (current.End == math.MaxInt || iv.Start <= current.End+1) {
+					current.End = max(current.End, iv.End)
+					continue
+				}
+				if started && !yield(current, current) {
+					return
+				}
+				current, started = iv, true
+			}
+			if started {
+				yield(current, current)
+			}
+		},
+	}
+}
@@ -0,0 +1,336 @@
+package flow
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelBatchSize is the number of elements handed to a worker at a time
+// by the parallel operations.
+const parallelBatchSize = 256
+
+// indexed tags a value with its position so results computed out of order
+// by concurrent workers can be reassembled in stream order.
+type indexed[T any] struct {
+	index int
+	value T
+}
+
+// batches splits the stream into contiguous, indexed batches sent on the returned
+// channel, which is closed once the stream is exhausted.
+func batches[T, R any](f Flow[T, R], size int) <-chan indexed[[]T] {
+	out := make(chan indexed[[]T])
+	go func() {
+		defer close(out)
+		index := 0
+		batch := make([]T, 0, size)
+		for k := range f.source {
+			batch = append(batch, k)
+			if len(batch) == size {
+				out <- indexed[[]T]{index: index, value: batch}
+				index++
+				batch = make([]T, 0, size)
+			}
+		}
+		if len(batch) > 0 {
+			out <- indexed[[]T]{index: index, value: batch}
+		}
+	}()
+	return out
+}
+
+// ParallelReduce reduces the stream concurrently using the given number of workers.
+// The stream is split into contiguous batches; each batch is folded with acc
+// starting from a fresh initial() value, and the partial results are then merged
+// with combine in stream order.
+// Because partials are combined in order, combine only needs to be associative,
+// not commutative (string concatenation works), but initial() must be an identity
+// for combine since it seeds every batch.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	total := flow.ParallelReduce(flow.Range(0, 1_000_000), 4,
+//	    func() int { return 0 },
+//	    func(acc, x int) int { return acc + x },
+//	    func(a, b int) int { return a + b },
+//	)
+func ParallelReduce[T, R, A any](f Flow[T, R], workers int, initial func() A, acc func(A, T) A, combine func(A, A) A) A {
+	if workers <= 0 {
+		panic("workers must be positive")
+	}
+
+	input := batches(f, parallelBatchSize)
+	partials := make(chan indexed[A])
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for batch := range input {
+				result := initial()
+				for _, v := range batch.value {
+					result = acc(result, v)
+				}
+				partials <- indexed[A]{index: batch.index, value: result}
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(partials)
+	}()
+
+	ordered := make(map[int]A)
+	for partial := range partials {
+		ordered[partial.index] = partial.value
+	}
+
+	result := initial()
+	for i := range len(ordered) {
+		result = combine(result, ordered[i])
+	}
+	return result
+}
+
+// TreeReduce reduces the stream concurrently with an associative operation.
+// The stream is materialized (flows created by NewFlow reuse their slice) and
+// split into one contiguous chunk per worker. The chunks are folded in parallel
+// starting from identity, and the partial results are then combined pairwise,
+// level by level, in a tree.
+// op MUST be associative and identity must be an identity for it, otherwise the
+// result differs from a serial Reduce. Operands are always combined in stream
+// order, so op does not need to be commutative.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	total := flow.TreeReduce(flow.NewFlow(samples), runtime.NumCPU(), 0.0,
+//	    func(a, b float64) float64 { return a + b },
+//	)
+func TreeReduce[T, R any](f Flow[T, R], workers int, identity T, op func(a, b T) T) T {
+	if workers <= 0 {
+		panic("workers must be positive")
+	}
+
+	values := f.values
+	if !f.sliceBacked {
+		values = f.Collect()
+	}
+	if len(values) == 0 {
+		return identity
+	}
+
+	chunks := slices.Collect(slices.Chunk(values, (len(values)+workers-1)/workers))
+	partials := make([]T, len(chunks))
+
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Go(func() {
+			result := identity
+			for _, v := range chunk {
+				result = op(result, v)
+			}
+			partials[i] = result
+		})
+	}
+	wg.Wait()
+
+	for len(partials) > 1 {
+		next := make([]T, (len(partials)+1)/2)
+		for i := range len(partials) / 2 {
+			wg.Go(func() {
+				next[i] = op(partials[2*i], partials[2*i+1])
+			})
+		}
+		if len(partials)%2 == 1 {
+			next[len(next)-1] = partials[len(partials)-1]
+		}
+		wg.Wait()
+		partials = next
+	}
+	return partials[0]
+}
+
+// TakeShared yields elements while a budget shared with other flows lasts.
+// A unit is atomically taken from *budget before each element is pulled from the
+// source, so several flows consumed concurrently emit at most the initial budget in
+// total and never pull an element they cannot yield. This makes it safe to share
+// one channel-backed source: no element is taken off the channel and dropped.
+// If the source ends after a unit was reserved, the unit is returned to the budget.
+// The budget never drops below zero.
+//
+// Example:
+//
+//	budget := int64(1000)
+//	for range workers {
+//	    go func() {
+//	        flow.TakeShared(flow.FromChannel(jobs), &budget).ForEach(process)
+//	    }()
+//	}
+func TakeShared[T, R any](f Flow[T, R], budget *int64) Flow[T, R] {
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			next, stop := iter.Pull2(f.source)
+			defer stop()
+
+			for takeOne(budget) {
+				k, v, ok := next()
+				if !ok {
+					atomic.AddInt64(budget, 1)
+					return
+				}
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// takeOne decrements *budget if it is positive and reports whether it did.
+func takeOne(budget *int64) bool {
+	for {
+		n := atomic.LoadInt64(budget)
+		if n <= 0 {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(budget, n, n-1) {
+			return true
+		}
+	}
+}
+
+// ParallelGroupBy groups elements by a key function using the given number of workers.
+// The stream is split into contiguous batches that are grouped concurrently into
+// local maps, which are then merged in stream order. Elements therefore keep
+// their stream order within each group, exactly as with GroupBy.
+// Parallelism pays off when keyFunc is expensive or the flow is large.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	byDomain := flow.ParallelGroupBy(flow.NewFlow(urls), 8, func(u string) string {
+//	    parsed, _ := url.Parse(u)
+//	    return parsed.Host
+//	})
+func ParallelGroupBy[T, R any, K comparable](f Flow[T, R], workers int, keyFunc func(T) K) map[K][]T {
+	if workers <= 0 {
+		panic("workers must be positive")
+	}
+
+	input := batches(f, parallelBatchSize)
+	partials := make(chan indexed[map[K][]T])
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for batch := range input {
+				local := make(map[K][]T)
+				for _, v := range batch.value {
+					key := keyFunc(v)
+					local[key] = append(local[key], v)
+				}
+				partials <- indexed[map[K][]T]{index: batch.index, value: local}
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(partials)
+	}()
+
+	ordered := make(map[int]map[K][]T)
+	for partial := range partials {
+		ordered[partial.index] = partial.value
+	}
+
+	result := make(map[K][]T)
+	for i := range len(ordered) {
+		for key, values := range ordered[i] {
+			result[key] = append(result[key], values...)
+		}
+	}
+	return result
+}
+
+// Pipeline is a sequence of same-type transformation stages that run concurrently.
+// Each stage runs in its own goroutine, connected to its neighbours by bounded
+// channels, so CPU-bound stages overlap while the order of elements is preserved.
+// Create one with NewPipeline.
+type Pipeline[T any] struct {
+	stages []func(T) T
+	buffer int
+}
+
+// NewPipeline creates an empty Pipeline whose stages are connected by channels
+// holding up to buffer elements.
+//
+// Example:
+//
+//	p := flow.NewPipeline[Image](4).
+//	    AddStage(decode).
+//	    AddStage(resize).
+//	    AddStage(compress)
+//	results := p.Run(flow.NewFlow(images)).Collect()
+func NewPipeline[T any](buffer int) *Pipeline[T] {
+	if buffer < 0 {
+		panic("pipeline buffer must not be negative")
+	}
+	return &Pipeline[T]{buffer: buffer}
+}
+
+// AddStage appends a transformation stage to the pipeline and returns the pipeline
+// to allow chaining.
+func (p *Pipeline[T]) AddStage(stage func(T) T) *Pipeline[T] {
+	p.stages = append(p.stages, stage)
+	return p
+}
+
+// Run returns a Flow that passes every element of f through all stages in order.
+// The stage goroutines are started when the flow is consumed and are signalled
+// to stop when the consumer stops early.
+//
+// Example:
+//
+//	doubledThenIncremented := flow.NewPipeline[int](1).
+//	    AddStage(func(x int) int { return x * 2 }).
+//	    AddStage(func(x int) int { return x + 1 }).
+//	    Run(flow.Range(0, 5)) // Stream of 1, 3, 5, 7, 9
+func (p *Pipeline[T]) Run(f Flow[T, T]) Flow[T, T] {
+	stages := slices.Clone(p.stages)
+	buffer := p.buffer
+
+	return Flow[T, T]{
+		source: func(yield func(T, T) bool) {
+			done := make(chan struct{})
+			defer close(done)
+
+			in := make(chan T, buffer)
+			go pump(f, in, done)
+
+			var current <-chan T = in
+			for _, stage := range stages {
+				out := make(chan T, buffer)
+				go func(in <-chan T, out chan<- T) {
+					defer close(out)
+					for v := range in {
+						select {
+						case out <- stage(v):
+						case <-done:
+							return
+						}
+					}
+				}(current, out)
+				current = out
+			}
+
+			for v := range current {
+				if !yield(v, v) {
+					return
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package flow
+
+// Result holds the outcome of a fallible computation: a value or an error.
+// A Result with a non-nil Err is a failure and its Value should be ignored.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// SplitResults separates a flow of Results into successful values and errors.
+// Both slices keep stream order. This is the usual way to process a batch of
+// fallible operations and report every failure at once.
+// This is a terminal operation that consumes the entire stream.
+//
+// Example:
+//
+//	results := flow.MapTo(flow.NewFlow(inputs), func(s string) flow.Result[int] {
+//	    n, err := strconv.Atoi(s)
+//	    return flow.Result[int]{Value: n, Err: err}
+//	})
+//	numbers, errs := flow.SplitResults(results)
+func SplitResults[T, R any](f Flow[Result[T], R]) ([]T, []error) {
+	var values []T
+	var errs []error
+	for res := range f.source {
+		if res.Err != nil {
+			errs = append(errs, res.Err)
+		} else {
+			values = append(values, res.Value)
+		}
+	}
+	return values, errs
+}
+
+// Validate checks elements in order and stops at the first one that fails.
+// It returns the 0-based index of that element and the error from check,
+// or (-1, nil) when every element passes.
+// This is a terminal operation that stops at the first failure.
+//
+// Example:
+//
+//	if i, err := flow.Validate(flow.NewFlow(rows), validateRow); err != nil {
+//	    return fmt.Errorf("row %d: %w", i, err)
+//	}
+func Validate[T, R any](f Flow[T, R], check func(T) error) (int, error) {
+	i := 0
+	for k := range f.source {
+		if err := check(k); err != nil {
+			return i, err
+		}
+		i++
+	}
+	return -1, nil
+}
@@ -0,0 +1,232 @@
+package flow_test
+
+import (
+	"testing"
+
+	. "github.com/MirrexOne/Flow"
+)
+
+func TestMinMax(t *testing.T) {
+	t.Run("Single element", func(t *testing.T) {
+		if v, ok := Min(Of(7)); !ok || v != 7 {
+			t.Errorf("Expected min 7, got %d (ok=%v)", v, ok)
+		}
+		if v, ok := Max(Of(7)); !ok || v != 7 {
+			t.Errorf("Expected max 7, got %d (ok=%v)", v, ok)
+		}
+	})
+
+	t.Run("Multiple elements", func(t *testing.T) {
+		data := Of(2.5, -1.0, 8.25, 0.0)
+
+		if v, ok := Min(data); !ok || v != -1.0 {
+			t.Errorf("Expected min -1, got %v (ok=%v)", v, ok)
+		}
+		if v, ok := Max(data); !ok || v != 8.25 {
+			t.Errorf("Expected max 8.25, got %v (ok=%v)", v, ok)
+		}
+	})
+
+	t.Run("Empty flow returns false", func(t *testing.T) {
+		if _, ok := Min(Empty[string]()); ok {
+			t.Error("Expected ok=false for empty flow")
+		}
+		if _, ok := Max(Empty[string]()); ok {
+			t.Error("Expected ok=false for empty flow")
+		}
+	})
+}
+
+func TestMinByMaxBy(t *testing.T) {
+	age := func(p person) int { return p.Age }
+
+	t.Run("Returns the element with the extreme key", func(t *testing.T) {
+		people := NewFlow([]person{{"Alice", 30}, {"Bob", 22}, {"Charlie", 41}, {"Dana", 35}})
+
+		if p, ok := MinBy(people, age); !ok || p.Name != "Bob" {
+			t.Errorf("Expected Bob, got %v (ok=%v)", p, ok)
+		}
+		if p, ok := MaxBy(people, age); !ok || p.Name != "Charlie" {
+			t.Errorf("Expected Charlie, got %v (ok=%v)", p, ok)
+		}
+	})
+
+	t.Run("Ties keep the first element", func(t *testing.T) {
+		people := NewFlow([]person{{"Alice", 30}, {"Bob", 20}, {"Carol", 20}, {"Dan", 30}})
+
+		if p, _ := MinBy(people, age); p.Name != "Bob" {
+			t.Errorf("Expected Bob, got %v", p)
+		}
+		if p, _ := MaxBy(people, age); p.Name != "Alice" {
+			t.Errorf("Expected Alice, got %v", p)
+		}
+	})
+
+	t.Run("Empty flow returns false", func(t *testing.T) {
+		if _, ok := MinBy(Empty[person](), age); ok {
+			t.Error("Expected ok=false for empty flow")
+		}
+		if _, ok := MaxBy(Empty[person](), age); ok {
+			t.Error("Expected ok=false for empty flow")
+		}
+	})
+}
+
+func TestMinMaxElem(t *testing.T) {
+	t.Run("Returns extremes", func(t *testing.T) {
+		data := Of(4, -2, 9, 0, 7)
+
+		if v, ok := MinElem(data); !ok || v != -2 {
+			t.Errorf("Expected min -2, got %d (ok=%v)", v, ok)
+		}
+		if v, ok := MaxElem(data); !ok || v != 9 {
+			t.Errorf("Expected max 9, got %d (ok=%v)", v, ok)
+		}
+	})
+
+	t.Run("Works with strings", func(t *testing.T) {
+		data := Of("pear", "apple", "plum")
+
+		if v, ok := MinElem(data); !ok || v != "apple" {
+			t.Errorf("Expected min apple, got %q", v)
+		}
+		if v, ok := MaxElem(data); !ok || v != "plum" {
+			t.Errorf("Expected max plum, got %q", v)
+		}
+	})
+
+	t.Run("Empty flow returns false", func(t *testing.T) {
+		if _, ok := MinElem(Empty[int]()); ok {
+			t.Error("Expected ok=false for empty flow")
+		}
+		if _, ok := MaxElem(Empty[int]()); ok {
+			t.Error("Expected ok=false for empty flow")
+		}
+	})
+}
+
+func TestAverageBy(t *testing.T) {
+	age := func(p person) float64 { return float64(p.Age) }
+
+	t.Run("Average age", func(t *testing.T) {
+		people := NewFlow([]person{{"Alice", 25}, {"Bob", 30}, {"Charlie", 35}, {"Dana", 31}})
+
+		avg, ok := AverageBy(people, age)
+		if !ok || avg != 30.25 {
+			t.Errorf("Expected 30.25, got %v (ok=%v)", avg, ok)
+		}
+	})
+
+	t.Run("Empty flow returns false", func(t *testing.T) {
+		if _, ok := AverageBy(Empty[person](), age); ok {
+			t.Error("Expected ok=false for empty flow")
+		}
+	})
+}
+
+func TestSum(t *testing.T) {
+	t.Run("Integers", func(t *testing.T) {
+		if sum := Sum(Range(1, 101)); sum != 5050 {
+			t.Errorf("Expected 5050, got %d", sum)
+		}
+	})
+
+	t.Run("Floats", func(t *testing.T) {
+		if sum := Sum(Of(1.5, 2.25, -0.75)); sum != 3.0 {
+			t.Errorf("Expected 3, got %v", sum)
+		}
+	})
+
+	t.Run("Empty flow sums to zero", func(t *testing.T) {
+		if sum := Sum(Empty[float64]()); sum != 0 {
+			t.Errorf("Expected 0, got %v", sum)
+		}
+	})
+}
+
+func TestCollectWithStats(t *testing.T) {
+	t.Run("Slice and stats agree", func(t *testing.T) {
+		values, stats := CollectWithStats(Of(3, 1, 4, 1, 5))
+
+		if len(values) != 5 || values[0] != 3 || values[4] != 5 {
+			t.Errorf("Expected [3 1 4 1 5], got %v", values)
+		}
+		if stats.Count != len(values) || stats.Sum != 14 || stats.Min != 1 || stats.Max != 5 {
+			t.Errorf("Unexpected stats %+v", stats)
+		}
+		if stats.Mean != 2.8 {
+			t.Errorf("Expected mean 2.8, got %v", stats.Mean)
+		}
+	})
+
+	t.Run("Floats", func(t *testing.T) {
+		_, stats := CollectWithStats(Of(-1.5, 2.5))
+		if stats.Min != -1.5 || stats.Max != 2.5 || stats.Mean != 0.5 {
+			t.Errorf("Unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		values, stats := CollectWithStats(Empty[int]())
+		if len(values) != 0 || stats != (NumericStats[int]{}) {
+			t.Errorf("Expected zero stats, got %v and %+v", values, stats)
+		}
+	})
+}
+
+func TestMapReduce(t *testing.T) {
+	square := func(x int) int { return x * x }
+	add := func(acc, x int) int { return acc + x }
+
+	t.Run("Sum of squares matches MapTo+Reduce", func(t *testing.T) {
+		data := Range(1, 11)
+
+		expected := MapTo(data, square).Reduce(0, add)
+		if result := MapReduce(data, square, 0, add); result != expected {
+			t.Errorf("Expected %d, got %d", expected, result)
+		}
+	})
+
+	t.Run("Different accumulator type", func(t *testing.T) {
+		totalLen := MapReduce(Of("a", "bb", "ccc"), func(s string) int { return len(s) }, int64(0),
+			func(acc int64, n int) int64 { return acc + int64(n) })
+
+		if totalLen != 6 {
+			t.Errorf("Expected 6, got %d", totalLen)
+		}
+	})
+
+	t.Run("Empty flow returns initial", func(t *testing.T) {
+		if result := MapReduce(Empty[int](), square, 42, add); result != 42 {
+			t.Errorf("Expected 42, got %d", result)
+		}
+	})
+}
+
+func TestReduceScan(t *testing.T) {
+	add := func(acc, x int) int { return acc + x }
+
+	t.Run("Steps end with the final value", func(t *testing.T) {
+		final, steps := ReduceScan(Of(1, 2, 3, 4), 0, add)
+
+		expected := []int{1, 3, 6, 10}
+		if len(steps) != len(expected) {
+			t.Fatalf("Expected steps %v, got %v", expected, steps)
+		}
+		for i := range steps {
+			if steps[i] != expected[i] {
+				t.Errorf("Expected steps %v, got %v", expected, steps)
+			}
+		}
+		if final != steps[len(steps)-1] {
+			t.Errorf("Expected final %d to equal last step %d", final, steps[len(steps)-1])
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		final, steps := ReduceScan(Empty[int](), 7, add)
+		if final != 7 || len(steps) != 0 {
+			t.Errorf("Expected (7, []), got (%d, %v)", final, steps)
+		}
+	})
+}
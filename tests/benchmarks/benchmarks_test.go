@@ -1,6 +1,8 @@
 package benchmarks_test
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
 	flow "github.com/MirrexOne/Flow"
@@ -257,3 +259,95 @@ func BenchmarkChunkOperations(b *testing.B) {
 		}
 	})
 }
+
+// Benchmark a single FilterAll stage against equivalent chained Filter calls
+func BenchmarkFilterAll(b *testing.B) {
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = i
+	}
+	isEven := func(x int) bool { return x%2 == 0 }
+	notDiv3 := func(x int) bool { return x%3 != 0 }
+	notDiv5 := func(x int) bool { return x%5 != 0 }
+
+	b.Run("Chained Filter", func(b *testing.B) {
+		b.ReportAllocs()
+		f := flow.NewFlow(data)
+		b.ResetTimer()
+		for b.Loop() {
+			result := f.Filter(isEven).Filter(notDiv3).Filter(notDiv5).Count()
+			_ = result
+		}
+	})
+
+	b.Run("FilterAll", func(b *testing.B) {
+		b.ReportAllocs()
+		f := flow.NewFlow(data)
+		b.ResetTimer()
+		for b.Loop() {
+			result := f.FilterAll(isEven, notDiv3, notDiv5).Count()
+			_ = result
+		}
+	})
+}
+
+// Benchmark concurrent grouping against serial GroupBy on a large flow
+func BenchmarkParallelGroupBy(b *testing.B) {
+	data := make([]int, 100_000)
+	for i := range data {
+		data[i] = i
+	}
+	keyFunc := func(x int) int { return x % 100 }
+
+	b.Run("GroupBy", func(b *testing.B) {
+		b.ReportAllocs()
+		f := flow.NewFlow(data)
+		b.ResetTimer()
+		for b.Loop() {
+			groups := flow.GroupBy(f, keyFunc)
+			_ = groups
+		}
+	})
+
+	b.Run("ParallelGroupBy", func(b *testing.B) {
+		b.ReportAllocs()
+		f := flow.NewFlow(data)
+		b.ResetTimer()
+		for b.Loop() {
+			groups := flow.ParallelGroupBy(f, 4, keyFunc)
+			_ = groups
+		}
+	})
+}
+
+// Benchmark tree reduction against serial Reduce for an associative operation
+func BenchmarkTreeReduce(b *testing.B) {
+	data := make([]float64, 1_000_000)
+	for i := range data {
+		data[i] = float64(i%1000) / 1000
+	}
+	// Euclidean norm accumulation is associative and costly enough to parallelize
+	op := func(a, b float64) float64 { return math.Hypot(a, b) }
+
+	b.Run("Reduce", func(b *testing.B) {
+		b.ReportAllocs()
+		f := flow.NewFlow(data)
+		b.ResetTimer()
+		for b.Loop() {
+			result := f.Reduce(0, op)
+			_ = result
+		}
+	})
+
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("TreeReduce-%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			f := flow.NewFlow(data)
+			b.ResetTimer()
+			for b.Loop() {
+				result := flow.TreeReduce(f, workers, 0, op)
+				_ = result
+			}
+		})
+	}
+}
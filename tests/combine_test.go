@@ -2,7 +2,9 @@ package flow_test
 
 import (
 	"fmt"
+	"runtime"
 	"testing"
+	"time"
 
 	. "github.com/MirrexOne/Flow"
 )
@@ -222,3 +224,152 @@ func TestCombineChaining(t *testing.T) {
 		}
 	})
 }
+
+func TestCombineLatest(t *testing.T) {
+	t.Run("Emits latest pair on every update", func(t *testing.T) {
+		numbers := make(chan int)
+		letters := make(chan string)
+
+		out := CombineLatest(FromChannel(numbers), FromChannel(letters)).ToChannel(0)
+
+		numbers <- 1
+		letters <- "a"
+		if pair := <-out; pair.First != 1 || pair.Second != "a" {
+			t.Errorf("Expected {1 a}, got %v", pair)
+		}
+
+		numbers <- 2
+		if pair := <-out; pair.First != 2 || pair.Second != "a" {
+			t.Errorf("Expected {2 a}, got %v", pair)
+		}
+
+		letters <- "b"
+		if pair := <-out; pair.First != 2 || pair.Second != "b" {
+			t.Errorf("Expected {2 b}, got %v", pair)
+		}
+
+		close(numbers)
+		letters <- "c"
+		if pair := <-out; pair.First != 2 || pair.Second != "c" {
+			t.Errorf("Expected {2 c}, got %v", pair)
+		}
+
+		close(letters)
+		if pair, ok := <-out; ok {
+			t.Errorf("Expected output to be closed, got %v", pair)
+		}
+	})
+
+	t.Run("No emission until both flows produce", func(t *testing.T) {
+		result := CombineLatest(Of(1, 2, 3), Empty[string]()).Collect()
+
+		if len(result) != 0 {
+			t.Errorf("Expected no pairs, got %v", result)
+		}
+	})
+
+	t.Run("Early stop shuts down once sources close", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+		numbers := make(chan int)
+		letters := make(chan string)
+
+		done := make(chan []Pair[int, string])
+		go func() {
+			done <- CombineLatest(FromChannel(numbers), FromChannel(letters)).Take(1).Collect()
+		}()
+		numbers <- 1
+		letters <- "a"
+
+		result := <-done
+		if len(result) != 1 || result[0].First != 1 || result[0].Second != "a" {
+			t.Fatalf("Expected [{1 a}], got %v", result)
+		}
+
+		// Both channels stay open until now, so the source goroutines are still
+		// blocked receiving; closing them lets the goroutines exit.
+		close(numbers)
+		close(letters)
+
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if after := runtime.NumGoroutine(); after > before {
+			t.Errorf("Expected goroutines to stop, had %d before and %d after", before, after)
+		}
+	})
+}
+
+func TestWithFirst(t *testing.T) {
+	t.Run("Pairs every element with the head", func(t *testing.T) {
+		result := WithFirst(Of(5, 10, 15)).Collect()
+
+		expected := []int{5, 10, 15}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %d pairs, got %d", len(expected), len(result))
+		}
+		for i, pair := range result {
+			if pair.First != expected[i] || pair.Second != 5 {
+				t.Errorf("At index %d: expected {%d 5}, got %v", i, expected[i], pair)
+			}
+		}
+	})
+
+	t.Run("Stays lazy on infinite flows", func(t *testing.T) {
+		result := WithFirst(Infinite(func(i int) int { return i + 1 })).Take(3).Collect()
+
+		if len(result) != 3 || result[2].First != 3 || result[2].Second != 1 {
+			t.Errorf("Expected 3 pairs ending with {3 1}, got %v", result)
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		result := WithFirst(Empty[int]()).Collect()
+		if len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+}
+
+func TestZipInto(t *testing.T) {
+	t.Run("Infinite index with finite data", func(t *testing.T) {
+		indexes := Infinite(func(i int) int { return i })
+		names := Of("Alice", "Bob", "Charlie")
+
+		result := ZipInto(indexes, names, func(i int, name string) string {
+			return fmt.Sprintf("%d:%s", i, name)
+		}).Collect()
+
+		expected := []string{"0:Alice", "1:Bob", "2:Charlie"}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %s, got %s", i, expected[i], result[i])
+			}
+		}
+	})
+
+	t.Run("Both flows infinite with Take", func(t *testing.T) {
+		squares := Infinite(func(i int) int { return i * i })
+		cubes := Infinite(func(i int) int { return i * i * i })
+
+		result := ZipInto(squares, cubes, func(a, b int) int { return a + b }).Take(3).Collect()
+
+		expected := []int{0, 2, 12}
+		for i := range expected {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %d, got %d", i, expected[i], result[i])
+			}
+		}
+	})
+
+	t.Run("Combine is lazy", func(t *testing.T) {
+		result := Combine(Infinite(func(i int) int { return i }), Of("a", "b")).Collect()
+
+		if len(result) != 2 || result[1].First != 1 || result[1].Second != "b" {
+			t.Errorf("Expected [{0 a} {1 b}], got %v", result)
+		}
+	})
+}
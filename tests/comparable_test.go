@@ -0,0 +1,42 @@
+package flow_test
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/MirrexOne/Flow"
+)
+
+func TestComparableFlow(t *testing.T) {
+	t.Run("Chain Dedup and Distinct", func(t *testing.T) {
+		data := AsComparable(Of(1, 1, 2, 2, 1, 3, 3))
+
+		if result := data.Dedup().Collect(); !slices.Equal(result, []int{1, 2, 1, 3}) {
+			t.Errorf("Dedup: expected [1 2 1 3], got %v", result)
+		}
+		if result := data.Dedup().Distinct().Collect(); !slices.Equal(result, []int{1, 2, 3}) {
+			t.Errorf("Dedup+Distinct: expected [1 2 3], got %v", result)
+		}
+	})
+
+	t.Run("Embedded Flow methods", func(t *testing.T) {
+		evens := AsComparable(Range(0, 10)).Filter(func(x int) bool { return x%2 == 0 })
+
+		if result := AsComparable(evens).Distinct().Count(); result != 5 {
+			t.Errorf("Expected 5 elements, got %d", result)
+		}
+	})
+
+	t.Run("ToSet", func(t *testing.T) {
+		set := AsComparable(Of("a", "b", "a", "c")).ToSet()
+
+		if len(set) != 3 {
+			t.Errorf("Expected 3 entries, got %v", set)
+		}
+		for _, key := range []string{"a", "b", "c"} {
+			if _, ok := set[key]; !ok {
+				t.Errorf("Expected %q in set", key)
+			}
+		}
+	})
+}
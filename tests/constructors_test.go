@@ -2,7 +2,9 @@ package flow_test
 
 import (
 	. "github.com/MirrexOne/Flow"
+	"runtime"
 	"testing"
+	"time"
 )
 
 func TestConstructors(t *testing.T) {
@@ -118,3 +120,91 @@ func TestVariadicConstructorsWithDifferentTypes(t *testing.T) {
 		}
 	})
 }
+
+func TestFromChannelEarlyTermination(t *testing.T) {
+	// filled returns a buffered channel holding values that is never closed,
+	// so reading past them would block forever.
+	filled := func(values ...int) chan int {
+		ch := make(chan int, len(values))
+		for _, v := range values {
+			ch <- v
+		}
+		return ch
+	}
+
+	// returnsPromptly runs fn and fails if it blocks, then checks no goroutine is left behind.
+	returnsPromptly := func(t *testing.T, fn func()) {
+		t.Helper()
+		before := runtime.NumGoroutine()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			fn()
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Expected the terminal to return without blocking")
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if after := runtime.NumGoroutine(); after > before {
+			t.Errorf("Expected no leaked goroutines, had %d before and %d after", before, after)
+		}
+	}
+
+	t.Run("First", func(t *testing.T) {
+		ch := filled(1, 2, 3)
+		var v int
+		var ok bool
+		returnsPromptly(t, func() { v, ok = FromChannel(ch).First() })
+
+		if !ok || v != 1 {
+			t.Errorf("Expected 1, got %d (ok=%v)", v, ok)
+		}
+		if len(ch) != 2 {
+			t.Errorf("Expected 2 unread values left in the channel, got %d", len(ch))
+		}
+	})
+
+	t.Run("Take", func(t *testing.T) {
+		ch := filled(1, 2, 3)
+		var result []int
+		returnsPromptly(t, func() { result = FromChannel(ch).Take(2).Collect() })
+
+		if len(result) != 2 || result[0] != 1 || result[1] != 2 {
+			t.Errorf("Expected [1 2], got %v", result)
+		}
+		if len(ch) != 1 {
+			t.Errorf("Expected 1 unread value left in the channel, got %d", len(ch))
+		}
+	})
+
+	t.Run("FindFirst", func(t *testing.T) {
+		ch := filled(1, 2, 3)
+		var v int
+		var ok bool
+		returnsPromptly(t, func() { v, ok = FromChannel(ch).FindFirst(func(x int) bool { return x == 2 }) })
+
+		if !ok || v != 2 {
+			t.Errorf("Expected 2, got %d (ok=%v)", v, ok)
+		}
+		if len(ch) != 1 {
+			t.Errorf("Expected 1 unread value left in the channel, got %d", len(ch))
+		}
+	})
+
+	t.Run("Channel is reusable afterwards", func(t *testing.T) {
+		ch := filled(1, 2, 3)
+		FromChannel(ch).First()
+		close(ch)
+
+		if rest := FromChannel(ch).Collect(); len(rest) != 2 || rest[0] != 2 || rest[1] != 3 {
+			t.Errorf("Expected [2 3], got %v", rest)
+		}
+	})
+}
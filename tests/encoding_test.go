@@ -0,0 +1,198 @@
+package flow_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strconv"
+	"testing"
+
+	. "github.com/MirrexOne/Flow"
+)
+
+type person struct {
+	Name string
+	Age  int
+}
+
+func TestToRows(t *testing.T) {
+	t.Run("Rows keep shape and order", func(t *testing.T) {
+		people := NewFlow([]person{{"Alice", 25}, {"Bob", 30}})
+		rows := ToRows(people, func(p person) []any { return []any{p.Name, p.Age} })
+
+		if len(rows) != 2 {
+			t.Fatalf("Expected 2 rows, got %d", len(rows))
+		}
+		for i, expected := range [][]any{{"Alice", 25}, {"Bob", 30}} {
+			if len(rows[i]) != len(expected) {
+				t.Fatalf("Row %d: expected %v, got %v", i, expected, rows[i])
+			}
+			for j := range expected {
+				if rows[i][j] != expected[j] {
+					t.Errorf("Row %d: expected %v, got %v", i, expected, rows[i])
+				}
+			}
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		rows := ToRows(Empty[int](), func(x int) []any { return []any{x} })
+		if len(rows) != 0 {
+			t.Errorf("Expected no rows, got %v", rows)
+		}
+	})
+}
+
+func TestToBitset(t *testing.T) {
+	t.Run("Set bits match input values", func(t *testing.T) {
+		values := []int{0, 3, 63, 64, 130}
+		bits := ToBitset(NewFlow(values), 130)
+
+		if len(bits) != 3 {
+			t.Fatalf("Expected 3 words, got %d", len(bits))
+		}
+		set := 0
+		for i := range 131 {
+			if bits[i/64]&(1<<(i%64)) != 0 {
+				set++
+				if !slices.Contains(values, i) {
+					t.Errorf("Unexpected bit %d set", i)
+				}
+			}
+		}
+		if set != len(values) {
+			t.Errorf("Expected %d bits set, got %d", len(values), set)
+		}
+	})
+
+	t.Run("Out-of-range values are skipped", func(t *testing.T) {
+		bits := ToBitset(Of(-1, 5, 11), 10)
+
+		if len(bits) != 1 || bits[0] != 1<<5 {
+			t.Errorf("Expected only bit 5 set, got %b", bits)
+		}
+	})
+}
+
+func TestToCSV(t *testing.T) {
+	toRecord := func(p person) []string { return []string{p.Name, strconv.Itoa(p.Age)} }
+
+	t.Run("Round-trips through encoding/csv", func(t *testing.T) {
+		people := []person{{"Alice", 25}, {"Bob, Jr.", 30}, {`Quote "Q"`, 41}}
+
+		data, err := ToCSV(NewFlow(people), toRecord, []string{"name", "age"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil {
+			t.Fatalf("Unexpected decode error: %v", err)
+		}
+		if len(records) != len(people)+1 || !slices.Equal(records[0], []string{"name", "age"}) {
+			t.Fatalf("Expected header and %d records, got %v", len(people), records)
+		}
+		for i, p := range people {
+			if !slices.Equal(records[i+1], toRecord(p)) {
+				t.Errorf("Record %d: expected %v, got %v", i, toRecord(p), records[i+1])
+			}
+		}
+	})
+
+	t.Run("Nil header is omitted", func(t *testing.T) {
+		data, err := ToCSV(Of(person{"Alice", 25}), toRecord, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(data) != "Alice,25\n" {
+			t.Errorf("Expected %q, got %q", "Alice,25\n", data)
+		}
+	})
+}
+
+func TestToJSONGroups(t *testing.T) {
+	people := []person{{"Alice", 30}, {"Bob", 25}, {"Carol", 30}, {"Dan", 41}}
+
+	t.Run("Sorted keys and stable output", func(t *testing.T) {
+		byAge := func(p person) int { return p.Age }
+
+		first, err := ToJSONGroups(NewFlow(people), byAge)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := `{"25":[{"Name":"Bob","Age":25}],"30":[{"Name":"Alice","Age":30},{"Name":"Carol","Age":30}],"41":[{"Name":"Dan","Age":41}]}`
+		if string(first) != expected {
+			t.Errorf("Expected %s, got %s", expected, first)
+		}
+
+		for range 20 {
+			again, _ := ToJSONGroups(NewFlow(people), byAge)
+			if !bytes.Equal(again, first) {
+				t.Fatalf("Expected stable output, got %s and %s", first, again)
+			}
+		}
+	})
+
+	t.Run("String keys", func(t *testing.T) {
+		data, err := ToJSONGroups(Of("kiwi", "apple", "avocado"), func(s string) string { return s[:1] })
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if expected := `{"a":["apple","avocado"],"k":["kiwi"]}`; string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+	})
+
+	t.Run("Unsupported key type", func(t *testing.T) {
+		type key struct{ A, B int }
+		if _, err := ToJSONGroups(Of(1, 2), func(x int) key { return key{x, x} }); err == nil {
+			t.Error("Expected an error for struct keys")
+		}
+	})
+}
+
+func TestGob(t *testing.T) {
+	t.Run("Round-trips a struct flow", func(t *testing.T) {
+		people := []person{{"Alice", 25}, {"Bob", 30}, {"Charlie", 35}}
+
+		var buf bytes.Buffer
+		if err := ToGob(NewFlow(people), &buf); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		decoded, errFn := FromGob[person](&buf)
+		result := decoded.Collect()
+		if err := errFn(); err != nil {
+			t.Fatalf("Unexpected decode error: %v", err)
+		}
+		if !slices.Equal(result, people) {
+			t.Errorf("Expected %v, got %v", people, result)
+		}
+	})
+
+	t.Run("Decodes lazily", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ToGob(Range(0, 100), &buf); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		decoded, _ := FromGob[int](&buf)
+		if result := decoded.Take(3).Collect(); !slices.Equal(result, []int{0, 1, 2}) {
+			t.Errorf("Expected [0 1 2], got %v", result)
+		}
+		if buf.Len() == 0 {
+			t.Error("Expected the rest of the stream to stay unread")
+		}
+	})
+
+	t.Run("Reports corrupt input", func(t *testing.T) {
+		decoded, errFn := FromGob[person](bytes.NewReader([]byte("not gob data")))
+
+		if count := decoded.Count(); count != 0 {
+			t.Errorf("Expected no elements, got %d", count)
+		}
+		if errFn() == nil {
+			t.Error("Expected a decode error")
+		}
+	})
+}
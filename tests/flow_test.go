@@ -2,7 +2,10 @@ package flow_test
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/MirrexOne/Flow"
 )
@@ -82,3 +85,528 @@ func TestFlowCorrectness(t *testing.T) {
 		}
 	})
 }
+
+func TestCollectProgress(t *testing.T) {
+	t.Run("Reports every n elements", func(t *testing.T) {
+		var reported []int
+		result := Range(0, 10).CollectProgress(3, func(n int) {
+			reported = append(reported, n)
+		})
+
+		if len(result) != 10 {
+			t.Errorf("Expected 10 elements, got %d", len(result))
+		}
+
+		expected := []int{3, 6, 9}
+		if len(reported) != len(expected) {
+			t.Fatalf("Expected callbacks %v, got %v", expected, reported)
+		}
+		for i := range reported {
+			if reported[i] != expected[i] {
+				t.Errorf("Expected callbacks %v, got %v", expected, reported)
+			}
+		}
+	})
+
+	t.Run("Panics on non-positive interval", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for zero interval")
+			}
+		}()
+		Range(0, 10).CollectProgress(0, func(int) {})
+	})
+}
+
+func TestToSlicePointers(t *testing.T) {
+	t.Run("Mutation reaches NewFlow source", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		for _, p := range NewFlow(data).ToSlicePointers() {
+			*p *= 10
+		}
+
+		expected := []int{10, 20, 30}
+		for i := range data {
+			if data[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, data)
+			}
+		}
+	})
+
+	t.Run("Derived flows are collected into a copy", func(t *testing.T) {
+		data := []int{1, 2, 3, 4}
+		pointers := NewFlow(data).Filter(func(x int) bool { return x%2 == 0 }).ToSlicePointers()
+
+		if len(pointers) != 2 || *pointers[0] != 2 || *pointers[1] != 4 {
+			t.Fatalf("Expected pointers to [2 4], got %d pointers", len(pointers))
+		}
+		*pointers[0] = 100
+		if data[1] != 2 {
+			t.Errorf("Expected source to be unchanged, got %v", data)
+		}
+	})
+
+	t.Run("Generator-backed flow", func(t *testing.T) {
+		pointers := Range(0, 3).ToSlicePointers()
+		if len(pointers) != 3 || *pointers[2] != 2 {
+			t.Errorf("Expected 3 pointers ending with 2, got %d pointers", len(pointers))
+		}
+	})
+}
+
+func TestCollectIndexed(t *testing.T) {
+	t.Run("Positions map to elements", func(t *testing.T) {
+		data := []string{"a", "b", "c"}
+		result := NewFlow(data).CollectIndexed()
+
+		if len(result) != len(data) {
+			t.Fatalf("Expected %d entries, got %v", len(data), result)
+		}
+		for i, v := range data {
+			if result[i] != v {
+				t.Errorf("Position %d: expected %s, got %s", i, v, result[i])
+			}
+		}
+	})
+
+	t.Run("ToIndexedSlice", func(t *testing.T) {
+		result := Range(10, 13).ToIndexedSlice()
+
+		if len(result) != 3 {
+			t.Fatalf("Expected 3 pairs, got %v", result)
+		}
+		for i, kv := range result {
+			if kv.Key != i || kv.Value != 10+i {
+				t.Errorf("Expected {%d %d}, got %v", i, 10+i, kv)
+			}
+		}
+	})
+}
+
+func TestMapUntil(t *testing.T) {
+	t.Run("Stops at the first false signal", func(t *testing.T) {
+		result := Of(10, 30, 60, 5).MapUntil(func(x int) (int, bool) {
+			return x * 2, x*2 <= 100
+		}).Collect()
+
+		if expected := []int{20, 60}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Does not advance past the stop", func(t *testing.T) {
+		pulled := 0
+		Infinite(func(i int) int { return i }).
+			Peek(func(int) { pulled++ }).
+			MapUntil(func(x int) (int, bool) { return x, x < 3 }).
+			Collect()
+
+		if pulled != 4 {
+			t.Errorf("Expected 4 elements pulled, got %d", pulled)
+		}
+	})
+}
+
+func TestCollectToBuilder(t *testing.T) {
+	t.Run("Matches a manual join", func(t *testing.T) {
+		format := func(n int) string { return fmt.Sprintf("[%d]", n) }
+
+		var sb strings.Builder
+		Range(1, 6).CollectToBuilder(&sb, format)
+
+		var parts []string
+		for n := 1; n < 6; n++ {
+			parts = append(parts, format(n))
+		}
+		if expected := strings.Join(parts, ""); sb.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, sb.String())
+		}
+	})
+
+	t.Run("Appends to existing content", func(t *testing.T) {
+		var sb strings.Builder
+		sb.WriteString("header;")
+		Of("a", "b").CollectToBuilder(&sb, func(s string) string { return s + ";" })
+
+		if expected := "header;a;b;"; sb.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, sb.String())
+		}
+	})
+}
+
+func TestBeforeFirst(t *testing.T) {
+	t.Run("Runs once before the first element", func(t *testing.T) {
+		var events []string
+		Of("a", "b", "c").
+			BeforeFirst(func() { events = append(events, "init") }).
+			ForEachFunc(func(s string) { events = append(events, s) })
+
+		expected := []string{"init", "a", "b", "c"}
+		if len(events) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, events)
+		}
+		for i := range events {
+			if events[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, events)
+			}
+		}
+	})
+
+	t.Run("Does not run for unconsumed or empty flows", func(t *testing.T) {
+		calls := 0
+		_ = Of(1, 2, 3).BeforeFirst(func() { calls++ })
+		Empty[int]().BeforeFirst(func() { calls++ }).Collect()
+
+		if calls != 0 {
+			t.Errorf("Expected no calls, got %d", calls)
+		}
+	})
+
+	t.Run("Runs on first pull of an infinite flow", func(t *testing.T) {
+		calls := 0
+		Infinite(func(i int) int { return i }).BeforeFirst(func() { calls++ }).First()
+
+		if calls != 1 {
+			t.Errorf("Expected 1 call, got %d", calls)
+		}
+	})
+}
+
+func TestCatch(t *testing.T) {
+	panicking := FromFunc(func(yield func(int, int) bool) {
+		for i := 1; i <= 3; i++ {
+			if !yield(i, i) {
+				return
+			}
+		}
+		panic("source failed")
+	})
+
+	t.Run("Switches to fallback after panic", func(t *testing.T) {
+		var recovered any
+		result := panicking.Catch(func(r any) Flow[int, int] {
+			recovered = r
+			return Of(100, 200)
+		}).Collect()
+
+		expected := []int{1, 2, 3, 100, 200}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+		}
+		if recovered != "source failed" {
+			t.Errorf("Expected recovered value %q, got %v", "source failed", recovered)
+		}
+	})
+
+	t.Run("Handler not called without panic", func(t *testing.T) {
+		called := false
+		result := Of(1, 2).Catch(func(any) Flow[int, int] {
+			called = true
+			return Empty[int]()
+		}).Collect()
+
+		if called || len(result) != 2 {
+			t.Errorf("Expected [1 2] without handler call, got %v (called=%v)", result, called)
+		}
+	})
+
+	t.Run("Downstream panics are not caught", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected downstream panic to propagate")
+			}
+		}()
+		Of(1, 2).
+			Catch(func(any) Flow[int, int] { return Empty[int]() }).
+			ForEachFunc(func(int) { panic("consumer failed") })
+	})
+}
+
+func TestObserve(t *testing.T) {
+	record := func(f Flow[int, int]) []string {
+		var events []string
+		f.Observe(
+			func(x int) { events = append(events, fmt.Sprint(x)) },
+			func() { events = append(events, "complete") },
+			func(err any) { events = append(events, fmt.Sprint("error: ", err)) },
+		)
+		return events
+	}
+
+	t.Run("Elements then completion", func(t *testing.T) {
+		events := record(Of(1, 2, 3))
+
+		expected := []string{"1", "2", "3", "complete"}
+		if !slices.Equal(events, expected) {
+			t.Errorf("Expected %v, got %v", expected, events)
+		}
+	})
+
+	t.Run("Empty flow only completes", func(t *testing.T) {
+		events := record(Empty[int]())
+
+		if expected := []string{"complete"}; !slices.Equal(events, expected) {
+			t.Errorf("Expected %v, got %v", expected, events)
+		}
+	})
+
+	t.Run("Panicking source reports error", func(t *testing.T) {
+		events := record(FromFunc(func(yield func(int, int) bool) {
+			if !yield(1, 1) {
+				return
+			}
+			panic("source failed")
+		}))
+
+		expected := []string{"1", "error: source failed"}
+		if !slices.Equal(events, expected) {
+			t.Errorf("Expected %v, got %v", expected, events)
+		}
+	})
+}
+
+func TestFilterAll(t *testing.T) {
+	t.Run("AND semantics", func(t *testing.T) {
+		result := Range(1, 31).FilterAll(
+			func(x int) bool { return x%2 == 0 },
+			func(x int) bool { return x%3 == 0 },
+		).Collect()
+
+		expected := []int{6, 12, 18, 24, 30}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+		}
+	})
+
+	t.Run("Short-circuits in order", func(t *testing.T) {
+		var calls []string
+		Of(1).FilterAll(
+			func(int) bool { calls = append(calls, "first"); return true },
+			func(int) bool { calls = append(calls, "second"); return false },
+			func(int) bool { calls = append(calls, "third"); return true },
+		).Collect()
+
+		if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+			t.Errorf("Expected [first second], got %v", calls)
+		}
+	})
+
+	t.Run("No predicates keeps everything", func(t *testing.T) {
+		if count := Range(0, 5).FilterAll().Count(); count != 5 {
+			t.Errorf("Expected 5 elements, got %d", count)
+		}
+	})
+}
+
+func TestFilterAny(t *testing.T) {
+	t.Run("OR semantics", func(t *testing.T) {
+		result := Range(1, 20).FilterAny(
+			func(x int) bool { return x%5 == 0 },
+			func(x int) bool { return x%7 == 0 },
+		).Collect()
+
+		expected := []int{5, 7, 10, 14, 15}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+		}
+	})
+
+	t.Run("Short-circuits on first match", func(t *testing.T) {
+		calls := 0
+		match := func(int) bool {
+			calls++
+			return true
+		}
+		Of(1, 2).FilterAny(match, match).Collect()
+
+		if calls != 2 {
+			t.Errorf("Expected one predicate call per element, got %d", calls)
+		}
+	})
+
+	t.Run("No predicates yields nothing", func(t *testing.T) {
+		if count := Range(0, 5).FilterAny().Count(); count != 0 {
+			t.Errorf("Expected 0 elements, got %d", count)
+		}
+	})
+}
+
+func TestForEachUntil(t *testing.T) {
+	t.Run("Stops at the signaling element", func(t *testing.T) {
+		pulled := 0
+		var seen []int
+		Infinite(func(i int) int { return i }).
+			Peek(func(int) { pulled++ }).
+			ForEachUntil(func(x int) bool {
+				seen = append(seen, x)
+				return x == 3
+			})
+
+		if len(seen) != 4 || seen[3] != 3 {
+			t.Errorf("Expected [0 1 2 3], got %v", seen)
+		}
+		if pulled != 4 {
+			t.Errorf("Expected source to be advanced 4 times, got %d", pulled)
+		}
+	})
+
+	t.Run("Consumes everything without stop", func(t *testing.T) {
+		count := 0
+		Range(0, 5).ForEachUntil(func(int) bool {
+			count++
+			return false
+		})
+
+		if count != 5 {
+			t.Errorf("Expected 5 calls, got %d", count)
+		}
+	})
+}
+
+func TestPeekIndexed(t *testing.T) {
+	t.Run("Sequential indices and unchanged elements", func(t *testing.T) {
+		var indices []int
+		var peeked []string
+		result := Of("a", "b", "c").
+			PeekIndexed(func(i int, s string) {
+				indices = append(indices, i)
+				peeked = append(peeked, s)
+			}).
+			Collect()
+
+		if len(result) != 3 || result[0] != "a" || result[2] != "c" {
+			t.Errorf("Expected [a b c] downstream, got %v", result)
+		}
+		for i := range indices {
+			if indices[i] != i || peeked[i] != result[i] {
+				t.Errorf("At %d: got index %d for %q", i, indices[i], peeked[i])
+			}
+		}
+	})
+
+	t.Run("Lazy", func(t *testing.T) {
+		calls := 0
+		Infinite(func(i int) int { return i }).PeekIndexed(func(int, int) { calls++ }).Take(3).Collect()
+
+		if calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", calls)
+		}
+	})
+}
+
+func TestPeekEvery(t *testing.T) {
+	t.Run("Samples every nth element", func(t *testing.T) {
+		var sampled []int
+		result := Range(0, 10).PeekEvery(3, func(x int) { sampled = append(sampled, x) }).Collect()
+
+		if expected := []int{0, 3, 6, 9}; !slices.Equal(sampled, expected) {
+			t.Errorf("Expected samples %v, got %v", expected, sampled)
+		}
+		if len(result) != 10 {
+			t.Errorf("Expected all 10 elements to pass through, got %v", result)
+		}
+	})
+
+	t.Run("Fires the expected number of times", func(t *testing.T) {
+		calls := 0
+		Range(0, 1000).PeekEvery(100, func(int) { calls++ }).Count()
+
+		if calls != 10 {
+			t.Errorf("Expected 10 calls, got %d", calls)
+		}
+	})
+
+	t.Run("Panics on non-positive step", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for zero step")
+			}
+		}()
+		Range(0, 3).PeekEvery(0, func(int) {})
+	})
+}
+
+func TestCollectReverse(t *testing.T) {
+	t.Run("Matches Collect then slices.Reverse", func(t *testing.T) {
+		data := Of("a", "b", "c", "d")
+
+		expected := data.Collect()
+		slices.Reverse(expected)
+
+		if result := data.CollectReverse(); !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Does not modify the source slice", func(t *testing.T) {
+		source := []int{1, 2, 3}
+		NewFlow(source).CollectReverse()
+
+		if !slices.Equal(source, []int{1, 2, 3}) {
+			t.Errorf("Expected source to be unchanged, got %v", source)
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		if result := Empty[int]().CollectReverse(); len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+}
+
+func TestToDoneChannel(t *testing.T) {
+	t.Run("Done closes after the last element", func(t *testing.T) {
+		data, done := Of(1, 2, 3).ToDoneChannel(0)
+
+		var result []int
+		for range 2 {
+			result = append(result, <-data)
+		}
+		select {
+		case <-done:
+			t.Fatal("Expected done to stay open while elements remain")
+		default:
+		}
+
+		result = append(result, <-data)
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Expected done to close after the last element")
+		}
+		if _, ok := <-data; ok {
+			t.Error("Expected the data channel to be closed")
+		}
+		if expected := []int{1, 2, 3}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Buffered elements drain after done", func(t *testing.T) {
+		data, done := Range(0, 5).ToDoneChannel(5)
+		<-done
+
+		var result []int
+		for v := range data {
+			result = append(result, v)
+		}
+		if expected := []int{0, 1, 2, 3, 4}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+}
@@ -0,0 +1,285 @@
+package flow_test
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/MirrexOne/Flow"
+)
+
+func TestGroupByInto(t *testing.T) {
+	t.Run("Accumulate across flows", func(t *testing.T) {
+		groups := make(map[bool][]int)
+		isEven := func(x int) bool { return x%2 == 0 }
+
+		GroupByInto(Range(1, 5), isEven, groups)
+		GroupByInto(Range(5, 9), isEven, groups)
+
+		if expected := []int{2, 4, 6, 8}; !slices.Equal(groups[true], expected) {
+			t.Errorf("Even group: expected %v, got %v", expected, groups[true])
+		}
+		if expected := []int{1, 3, 5, 7}; !slices.Equal(groups[false], expected) {
+			t.Errorf("Odd group: expected %v, got %v", expected, groups[false])
+		}
+	})
+
+	t.Run("Empty flow leaves map untouched", func(t *testing.T) {
+		groups := map[int][]int{1: {1}}
+		GroupByInto(Empty[int](), func(x int) int { return x }, groups)
+
+		if len(groups) != 1 || len(groups[1]) != 1 {
+			t.Errorf("Expected map to be unchanged, got %v", groups)
+		}
+	})
+}
+
+func TestStreamingGroupBy(t *testing.T) {
+	t.Run("Groups contiguous keys", func(t *testing.T) {
+		groups := StreamingGroupBy(Of(1, 1, 2, 2, 2, 3), func(x int) int { return x }).Collect()
+
+		if len(groups) != 3 {
+			t.Fatalf("Expected 3 groups, got %v", groups)
+		}
+		sizes := []int{2, 3, 1}
+		for i, group := range groups {
+			if group.Key != i+1 || len(group.Value) != sizes[i] {
+				t.Errorf("Group %d: expected key %d with %d elements, got %v", i, i+1, sizes[i], group)
+			}
+		}
+	})
+
+	t.Run("Emits incrementally on infinite flows", func(t *testing.T) {
+		consumed := 0
+		source := Infinite(func(i int) int { return i }).Peek(func(int) { consumed++ })
+		groups := StreamingGroupBy(source, func(x int) int { return x / 3 }).Take(2).Collect()
+
+		if len(groups) != 2 || !slices.Equal(groups[1].Value, []int{3, 4, 5}) {
+			t.Errorf("Expected second group [3 4 5], got %v", groups)
+		}
+		// The second group is only known to be complete once 6 has been seen.
+		if consumed != 7 {
+			t.Errorf("Expected 7 elements consumed, got %d", consumed)
+		}
+	})
+
+	t.Run("Non-contiguous keys produce separate groups", func(t *testing.T) {
+		groups := StreamingGroupBy(Of("a", "a", "b", "a"), func(s string) string { return s }).Collect()
+
+		if len(groups) != 3 || groups[0].Key != "a" || groups[2].Key != "a" {
+			t.Errorf("Expected groups a, b, a, got %v", groups)
+		}
+	})
+}
+
+func TestExplodeGroups(t *testing.T) {
+	t.Run("Round-trips with GroupByFlow", func(t *testing.T) {
+		data := Range(1, 11)
+		mod := func(x int) int { return x % 3 }
+
+		pairs := ExplodeGroups(GroupByFlow(data, mod)).Collect()
+
+		if len(pairs) != 10 {
+			t.Fatalf("Expected 10 pairs, got %d", len(pairs))
+		}
+		values := make([]int, 0, len(pairs))
+		for _, kv := range pairs {
+			if kv.Key != mod(kv.Value) {
+				t.Errorf("Value %d carries key %d, expected %d", kv.Value, kv.Key, mod(kv.Value))
+			}
+			values = append(values, kv.Value)
+		}
+		slices.Sort(values)
+		if !slices.Equal(values, data.Collect()) {
+			t.Errorf("Expected original elements, got %v", values)
+		}
+	})
+
+	t.Run("Empty groups are skipped", func(t *testing.T) {
+		groups := Of(
+			KeyValue[string, []int]{Key: "a", Value: nil},
+			KeyValue[string, []int]{Key: "b", Value: []int{1, 2}},
+		)
+		pairs := ExplodeGroups(groups).Collect()
+
+		if len(pairs) != 2 || pairs[0].Key != "b" || pairs[1].Value != 2 {
+			t.Errorf("Expected [{b 1} {b 2}], got %v", pairs)
+		}
+	})
+}
+
+func TestGroupByNested(t *testing.T) {
+	type employee struct {
+		Name       string
+		Department string
+		Role       string
+	}
+
+	t.Run("Department then role", func(t *testing.T) {
+		staff := NewFlow([]employee{
+			{"Alice", "R&D", "Engineer"},
+			{"Bob", "R&D", "Manager"},
+			{"Carol", "Sales", "Manager"},
+			{"Dave", "R&D", "Engineer"},
+		})
+		groups := GroupByNested(staff,
+			func(e employee) string { return e.Department },
+			func(e employee) string { return e.Role },
+		)
+
+		if len(groups) != 2 || len(groups["R&D"]) != 2 || len(groups["Sales"]) != 1 {
+			t.Fatalf("Unexpected grouping shape: %v", groups)
+		}
+		engineers := groups["R&D"]["Engineer"]
+		if len(engineers) != 2 || engineers[0].Name != "Alice" || engineers[1].Name != "Dave" {
+			t.Errorf("Expected engineers Alice and Dave, got %v", engineers)
+		}
+		if managers := groups["Sales"]["Manager"]; len(managers) != 1 || managers[0].Name != "Carol" {
+			t.Errorf("Expected Sales manager Carol, got %v", managers)
+		}
+	})
+}
+
+func TestDistinctValuesBy(t *testing.T) {
+	t.Run("Unique keys in first-appearance order", func(t *testing.T) {
+		words := Of("banana", "apple", "blueberry", "cherry", "avocado")
+		initials := DistinctValuesBy(words, func(s string) byte { return s[0] })
+
+		expected := []byte{'b', 'a', 'c'}
+		if !slices.Equal(initials, expected) {
+			t.Errorf("Expected %q, got %q", expected, initials)
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		if keys := DistinctValuesBy(Empty[int](), func(x int) int { return x }); len(keys) != 0 {
+			t.Errorf("Expected no keys, got %v", keys)
+		}
+	})
+}
+
+func TestDistinctByMax(t *testing.T) {
+	type record struct {
+		ID      string
+		Version int
+		Payload string
+	}
+
+	t.Run("Latest version per ID survives", func(t *testing.T) {
+		records := Of(
+			record{"a", 1, "a-v1"},
+			record{"b", 2, "b-v2"},
+			record{"a", 3, "a-v3"},
+			record{"c", 1, "c-v1"},
+			record{"b", 1, "b-v1"},
+			record{"a", 2, "a-v2"},
+		)
+
+		result := DistinctByMax(records,
+			func(r record) string { return r.ID },
+			func(r record) int { return r.Version },
+		)
+
+		var payloads []string
+		for _, r := range result {
+			payloads = append(payloads, r.Payload)
+		}
+		if expected := []string{"a-v3", "b-v2", "c-v1"}; !slices.Equal(payloads, expected) {
+			t.Errorf("Expected %v, got %v", expected, payloads)
+		}
+	})
+
+	t.Run("Ties keep the earliest element", func(t *testing.T) {
+		records := Of(record{"a", 1, "first"}, record{"a", 1, "second"})
+
+		result := DistinctByMax(records,
+			func(r record) string { return r.ID },
+			func(r record) int { return r.Version },
+		)
+
+		if len(result) != 1 || result[0].Payload != "first" {
+			t.Errorf("Expected the first element to win the tie, got %v", result)
+		}
+	})
+}
+
+func TestGroupByRunning(t *testing.T) {
+	t.Run("Each emission reflects the group at that point", func(t *testing.T) {
+		snapshots := GroupByRunning(Of(1, 2, 3, 4, 5), func(x int) bool { return x%2 == 0 }).Collect()
+
+		expected := []KeyValue[bool, []int]{
+			{Key: false, Value: []int{1}},
+			{Key: true, Value: []int{2}},
+			{Key: false, Value: []int{1, 3}},
+			{Key: true, Value: []int{2, 4}},
+			{Key: false, Value: []int{1, 3, 5}},
+		}
+		if len(snapshots) != len(expected) {
+			t.Fatalf("Expected %d snapshots, got %v", len(expected), snapshots)
+		}
+		for i := range snapshots {
+			if snapshots[i].Key != expected[i].Key || !slices.Equal(snapshots[i].Value, expected[i].Value) {
+				t.Errorf("Snapshot %d: expected %v, got %v", i, expected[i], snapshots[i])
+			}
+		}
+	})
+}
+
+func TestWithKey(t *testing.T) {
+	t.Run("Each element carries its key", func(t *testing.T) {
+		words := []string{"apple", "bob", "cherry", "fig"}
+		result := WithKey(NewFlow(words), func(s string) int { return len(s) }).Collect()
+
+		if len(result) != len(words) {
+			t.Fatalf("Expected %d pairs, got %v", len(words), result)
+		}
+		for i, kv := range result {
+			if kv.Value != words[i] || kv.Key != len(words[i]) {
+				t.Errorf("At index %d: expected {%d %s}, got %v", i, len(words[i]), words[i], kv)
+			}
+		}
+	})
+
+	t.Run("Lazy on infinite flows", func(t *testing.T) {
+		calls := 0
+		result := WithKey(Infinite(func(i int) int { return i }), func(x int) bool {
+			calls++
+			return x%2 == 0
+		}).Take(3).Collect()
+
+		expected := []KeyValue[bool, int]{{Key: true, Value: 0}, {Key: false, Value: 1}, {Key: true, Value: 2}}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 key computations, got %d", calls)
+		}
+	})
+}
+
+func TestGroupByIndex(t *testing.T) {
+	t.Run("Round-robin assignment", func(t *testing.T) {
+		groups := GroupByIndex(Of("a", "b", "c", "d", "e", "f", "g"), 3)
+
+		expected := [][]string{{"a", "d", "g"}, {"b", "e"}, {"c", "f"}}
+		if !slices.EqualFunc(groups, expected, slices.Equal) {
+			t.Errorf("Expected %v, got %v", expected, groups)
+		}
+	})
+
+	t.Run("More groups than elements", func(t *testing.T) {
+		groups := GroupByIndex(Range(0, 2), 4)
+
+		if len(groups) != 4 || len(groups[2]) != 0 || len(groups[3]) != 0 {
+			t.Errorf("Expected 4 groups with the last two empty, got %v", groups)
+		}
+	})
+
+	t.Run("Panics on non-positive group count", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for zero groups")
+			}
+		}()
+		GroupByIndex(Range(0, 3), 0)
+	})
+}
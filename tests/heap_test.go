@@ -0,0 +1,112 @@
+package flow_test
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/MirrexOne/Flow"
+)
+
+func TestToHeap(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	t.Run("Pop returns elements in sorted order", func(t *testing.T) {
+		data := []int{5, 1, 8, 3, 9, 2, 7, 3}
+		h := ToHeap(NewFlow(data), less)
+
+		if h.Len() != len(data) {
+			t.Fatalf("Expected %d elements, got %d", len(data), h.Len())
+		}
+		var result []int
+		for h.Len() > 0 {
+			v, _ := h.Pop()
+			result = append(result, v)
+		}
+
+		expected := slices.Sorted(slices.Values(data))
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Peek does not remove", func(t *testing.T) {
+		h := ToHeap(Of(4, 2, 6), less)
+
+		if v, ok := h.Peek(); !ok || v != 2 {
+			t.Errorf("Expected 2, got %d (ok=%v)", v, ok)
+		}
+		if h.Len() != 3 {
+			t.Errorf("Expected Peek to keep 3 elements, got %d", h.Len())
+		}
+	})
+
+	t.Run("Push keeps the order", func(t *testing.T) {
+		h := ToHeap(Of(4, 2, 6), less)
+		h.Push(1)
+		h.Push(5)
+
+		var result []int
+		for h.Len() > 0 {
+			v, _ := h.Pop()
+			result = append(result, v)
+		}
+		if expected := []int{1, 2, 4, 5, 6}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Empty heap", func(t *testing.T) {
+		h := ToHeap(Empty[int](), less)
+
+		if _, ok := h.Peek(); ok {
+			t.Error("Expected Peek to report an empty heap")
+		}
+		if _, ok := h.Pop(); ok {
+			t.Error("Expected Pop to report an empty heap")
+		}
+	})
+}
+
+func TestMergeSortedTopK(t *testing.T) {
+	t.Run("Matches a full merge followed by Take", func(t *testing.T) {
+		sources := [][]int{{1, 4, 9, 12}, {2, 3, 10}, {}, {0, 5, 5, 6, 20}}
+
+		var flows []Flow[int, int]
+		var all []int
+		for _, s := range sources {
+			flows = append(flows, NewFlow(s))
+			all = append(all, s...)
+		}
+		slices.Sort(all)
+
+		for _, k := range []int{1, 5, 8, len(all), len(all) + 3} {
+			result := MergeSortedTopK(k, flows...)
+			expected := all[:min(k, len(all))]
+			if !slices.Equal(result, expected) {
+				t.Errorf("k=%d: expected %v, got %v", k, expected, result)
+			}
+		}
+	})
+
+	t.Run("Stops early", func(t *testing.T) {
+		pulled := 0
+		evens := Infinite(func(i int) int { return i * 2 }).Peek(func(int) { pulled++ })
+		odds := Infinite(func(i int) int { return i*2 + 1 }).Peek(func(int) { pulled++ })
+
+		result := MergeSortedTopK(5, evens, odds)
+
+		if expected := []int{0, 1, 2, 3, 4}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		// Two initial heads plus one refill for every element but the last
+		if pulled != 6 {
+			t.Errorf("Expected 6 elements pulled, got %d", pulled)
+		}
+	})
+
+	t.Run("Non-positive k", func(t *testing.T) {
+		if result := MergeSortedTopK(0, Of(1, 2)); len(result) != 0 {
+			t.Errorf("Expected no elements, got %v", result)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package flow_test
+
+import (
+	"testing"
+
+	. "github.com/MirrexOne/Flow"
+)
+
+type order struct {
+	ID         int
+	CustomerID int
+}
+
+type customer struct {
+	ID   int
+	Name string
+}
+
+func orderCustomer(o order) int { return o.CustomerID }
+
+func customerID(c customer) int { return c.ID }
+
+func TestMergeJoin(t *testing.T) {
+	t.Run("Joins sorted flows skipping unmatched keys", func(t *testing.T) {
+		orders := Of(order{1, 1}, order{2, 1}, order{3, 2}, order{4, 4}, order{5, 5})
+		customers := Of(customer{1, "Alice"}, customer{3, "Carol"}, customer{4, "Dave"}, customer{5, "Eve"})
+
+		result := MergeJoin(orders, customers, orderCustomer, customerID).Collect()
+
+		expected := []struct {
+			OrderID int
+			Name    string
+		}{{1, "Alice"}, {2, "Alice"}, {4, "Dave"}, {5, "Eve"}}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %d pairs, got %v", len(expected), result)
+		}
+		for i, pair := range result {
+			if pair.First.ID != expected[i].OrderID || pair.Second.Name != expected[i].Name {
+				t.Errorf("At index %d: expected %v, got %v", i, expected[i], pair)
+			}
+		}
+	})
+
+	t.Run("Many-to-many produces the cross product per key", func(t *testing.T) {
+		left := Of(1, 2, 2, 3)
+		right := Of(2, 2, 3, 3)
+		identity := func(x int) int { return x }
+
+		if count := MergeJoin(left, right, identity, identity).Count(); count != 6 {
+			t.Errorf("Expected 6 pairs, got %d", count)
+		}
+	})
+
+	t.Run("Empty side", func(t *testing.T) {
+		result := MergeJoin(Empty[order](), Of(customer{1, "Alice"}), orderCustomer, customerID).Collect()
+		if len(result) != 0 {
+			t.Errorf("Expected no pairs, got %v", result)
+		}
+	})
+}
+
+func TestHashJoin(t *testing.T) {
+	t.Run("One-to-many inner join", func(t *testing.T) {
+		customers := Of(customer{2, "Bob"}, customer{1, "Alice"}, customer{9, "Nobody"})
+		orders := Of(order{1, 1}, order{2, 2}, order{3, 1}, order{4, 1})
+
+		result := HashJoin(customers, orders, customerID, orderCustomer).Collect()
+
+		expected := []struct {
+			Name    string
+			OrderID int
+		}{{"Bob", 2}, {"Alice", 1}, {"Alice", 3}, {"Alice", 4}}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %d pairs, got %v", len(expected), result)
+		}
+		for i, pair := range result {
+			if pair.First.Name != expected[i].Name || pair.Second.ID != expected[i].OrderID {
+				t.Errorf("At index %d: expected %v, got %v", i, expected[i], pair)
+			}
+		}
+	})
+
+	t.Run("Unmatched left elements are skipped", func(t *testing.T) {
+		result := HashJoin(Of(customer{7, "Ghost"}), Of(order{1, 1}), customerID, orderCustomer).Collect()
+		if len(result) != 0 {
+			t.Errorf("Expected no pairs, got %v", result)
+		}
+	})
+}
+
+func TestLeftJoin(t *testing.T) {
+	t.Run("Unmatched left elements have nil right", func(t *testing.T) {
+		customers := Of(customer{1, "Alice"}, customer{2, "Bob"}, customer{3, "Carol"})
+		orders := Of(order{10, 1}, order{11, 3}, order{12, 1})
+
+		result := LeftJoin(customers, orders, customerID, orderCustomer).Collect()
+
+		expected := []struct {
+			Name    string
+			OrderID int
+		}{{"Alice", 10}, {"Alice", 12}, {"Bob", 0}, {"Carol", 11}}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %d pairs, got %v", len(expected), result)
+		}
+		for i, pair := range result {
+			if pair.First.Name != expected[i].Name {
+				t.Errorf("At index %d: expected %s, got %s", i, expected[i].Name, pair.First.Name)
+			}
+			if expected[i].OrderID == 0 {
+				if pair.Second != nil {
+					t.Errorf("At index %d: expected nil order, got %v", i, *pair.Second)
+				}
+				continue
+			}
+			if pair.Second == nil || pair.Second.ID != expected[i].OrderID {
+				t.Errorf("At index %d: expected order %d, got %v", i, expected[i].OrderID, pair.Second)
+			}
+		}
+	})
+
+	t.Run("Empty right keeps every left element", func(t *testing.T) {
+		result := LeftJoin(Of(customer{1, "Alice"}), Empty[order](), customerID, orderCustomer).Collect()
+		if len(result) != 1 || result[0].Second != nil {
+			t.Errorf("Expected one unmatched pair, got %v", result)
+		}
+	})
+}
+
+func TestZipByKey(t *testing.T) {
+	t.Run("Pairs matching keys one to one", func(t *testing.T) {
+		orders := Of(order{1, 1}, order{2, 2}, order{3, 1}, order{4, 5})
+		customers := Of(customer{2, "Bob"}, customer{1, "Alice"}, customer{9, "Nobody"})
+
+		result := ZipByKey(orders, customers, orderCustomer, customerID).Collect()
+
+		expected := []struct {
+			OrderID int
+			Name    string
+		}{{1, "Alice"}, {2, "Bob"}}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %d pairs, got %v", len(expected), result)
+		}
+		for i, pair := range result {
+			if pair.First.ID != expected[i].OrderID || pair.Second.Name != expected[i].Name {
+				t.Errorf("At index %d: expected %v, got %v", i, expected[i], pair)
+			}
+		}
+	})
+
+	t.Run("Duplicate keys pair in order", func(t *testing.T) {
+		left := Of("a1", "b1", "a2", "a3")
+		right := Of("a-x", "a-y", "b-z")
+		first := func(s string) byte { return s[0] }
+
+		result := ZipByKey(left, right, first, first).Collect()
+
+		expected := []Pair[string, string]{{First: "a1", Second: "a-x"}, {First: "b1", Second: "b-z"}, {First: "a2", Second: "a-y"}}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %v, got %v", i, expected[i], result[i])
+			}
+		}
+	})
+}
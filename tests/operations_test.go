@@ -1,6 +1,11 @@
 package flow_test
 
 import (
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
 	. "github.com/MirrexOne/Flow"
@@ -40,6 +45,160 @@ func TestDistinct(t *testing.T) {
 	})
 }
 
+func TestDistinctBy(t *testing.T) {
+	t.Run("Dedupe people by name", func(t *testing.T) {
+		people := NewFlow([]person{{"Alice", 30}, {"Bob", 25}, {"Alice", 41}, {"Carol", 35}, {"Bob", 52}})
+		result := DistinctBy(people, func(p person) string { return p.Name }).Collect()
+
+		expected := []person{{"Alice", 30}, {"Bob", 25}, {"Carol", 35}}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Lazy on infinite flows", func(t *testing.T) {
+		result := DistinctBy(Infinite(func(i int) int { return i }), func(x int) int { return x % 3 }).Take(3).Collect()
+
+		if expected := []int{0, 1, 2}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+}
+
+func TestDistinctWindow(t *testing.T) {
+	t.Run("Value outside the window is re-emitted", func(t *testing.T) {
+		result := DistinctWindow(Of(1, 2, 1, 3, 4, 1, 4), 2).Collect()
+
+		expected := []int{1, 2, 3, 4, 1}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %d, got %d", i, expected[i], result[i])
+			}
+		}
+	})
+
+	t.Run("Large window behaves like Distinct", func(t *testing.T) {
+		data := Of(1, 2, 2, 3, 1, 3)
+		result := DistinctWindow(data, 100).Collect()
+
+		if len(result) != 3 || result[0] != 1 || result[1] != 2 || result[2] != 3 {
+			t.Errorf("Expected [1 2 3], got %v", result)
+		}
+	})
+}
+
+func TestChanged(t *testing.T) {
+	t.Run("Tolerance-based comparator", func(t *testing.T) {
+		closeEnough := func(a, b float64) bool { return math.Abs(a-b) < 0.5 }
+		result := Changed(Of(1.0, 1.2, 1.4, 1.6, 1.7, 3.0, 2.9), closeEnough).Collect()
+
+		expected := []float64{1.0, 1.6, 3.0}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		result := Changed(Empty[int](), func(a, b int) bool { return a == b }).Collect()
+		if len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+}
+
+func TestTakeUntilRepeat(t *testing.T) {
+	t.Run("Stops when the sequence cycles", func(t *testing.T) {
+		pulled := 0
+		cycle := FromFunc(func(yield func(int, int) bool) {
+			for x := 2; ; x = x * x % 11 {
+				pulled++
+				if !yield(x, x) {
+					return
+				}
+			}
+		})
+
+		result := TakeUntilRepeat(cycle).Collect()
+
+		// 2 → 4 → 5 → 3 → 9 → 4 cycles back to 4
+		expected := []int{2, 4, 5, 3, 9}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		if pulled != 6 {
+			t.Errorf("Expected 6 elements pulled, got %d", pulled)
+		}
+	})
+
+	t.Run("Fixed point", func(t *testing.T) {
+		result := TakeUntilRepeat(Infinite(func(i int) int { return min(i, 3) })).Collect()
+		if !slices.Equal(result, []int{0, 1, 2, 3}) {
+			t.Errorf("Expected [0 1 2 3], got %v", result)
+		}
+	})
+}
+
+func TestFilterStateful(t *testing.T) {
+	increasing := func(max *int, x int) bool {
+		if x <= *max {
+			return false
+		}
+		*max = x
+		return true
+	}
+
+	t.Run("Keep only increasing values", func(t *testing.T) {
+		result := FilterStateful(Of(3, 1, 4, 1, 5, 9, 2, 6), math.MinInt, increasing).Collect()
+
+		expected := []int{3, 4, 5, 9}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("State resets on each consumption", func(t *testing.T) {
+		f := FilterStateful(Of(1, 2, 3), math.MinInt, increasing)
+		f.Collect()
+
+		if result := f.Collect(); !slices.Equal(result, []int{1, 2, 3}) {
+			t.Errorf("Expected [1 2 3] on second consumption, got %v", result)
+		}
+	})
+}
+
+func TestFilterIndexed(t *testing.T) {
+	t.Run("Even positions", func(t *testing.T) {
+		result := FilterIndexed(Of(10, 20, 30, 40), func(i, x int) bool { return i%2 == 0 }).Collect()
+
+		if expected := []int{10, 30}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Index counts dropped elements too", func(t *testing.T) {
+		var seen []int
+		FilterIndexed(Of("a", "b", "c"), func(i int, s string) bool {
+			seen = append(seen, i)
+			return s != "b"
+		}).Collect()
+
+		if expected := []int{0, 1, 2}; !slices.Equal(seen, expected) {
+			t.Errorf("Expected indices %v, got %v", expected, seen)
+		}
+	})
+
+	t.Run("Lazy on infinite flows", func(t *testing.T) {
+		result := FilterIndexed(Infinite(func(i int) int { return i }), func(i, x int) bool { return i%3 == 0 }).Take(3).Collect()
+
+		if expected := []int{0, 3, 6}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+}
+
 func TestMapTo(t *testing.T) {
 	t.Run("Int to string", func(t *testing.T) {
 		data := Range(1, 4)
@@ -59,6 +218,321 @@ func TestMapTo(t *testing.T) {
 	})
 }
 
+func TestMapIndexed(t *testing.T) {
+	t.Run("Pairs values with positions", func(t *testing.T) {
+		result := MapIndexed(Of("a", "b", "c"), func(i int, s string) string {
+			return strconv.Itoa(i) + ":" + s
+		}).Collect()
+
+		if expected := []string{"0:a", "1:b", "2:c"}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Index counts the filtered stream", func(t *testing.T) {
+		evens := Range(0, 10).Filter(func(x int) bool { return x%2 == 0 })
+		result := MapIndexed(evens, func(i, x int) int { return i }).Collect()
+
+		if expected := []int{0, 1, 2, 3, 4}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Lazy on infinite flows", func(t *testing.T) {
+		result := MapIndexed(Infinite(func(i int) int { return i * 10 }), func(i, x int) int { return i + x }).Take(3).Collect()
+
+		if expected := []int{0, 11, 22}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+}
+
+func TestRoundTo(t *testing.T) {
+	t.Run("Various decimal places", func(t *testing.T) {
+		data := Of(3.14159, -2.71828, 0.0)
+
+		cases := map[int][]float64{
+			0: {3, -3, 0},
+			2: {3.14, -2.72, 0},
+			4: {3.1416, -2.7183, 0},
+		}
+		for decimals, expected := range cases {
+			if result := RoundTo(data, decimals).Collect(); !slices.Equal(result, expected) {
+				t.Errorf("decimals=%d: expected %v, got %v", decimals, expected, result)
+			}
+		}
+	})
+
+	t.Run("Midpoints round half away from zero", func(t *testing.T) {
+		result := RoundTo(Of(0.5, 2.5, -2.5, 1.25, -1.25), 1).Collect()
+		if expected := []float64{0.5, 2.5, -2.5, 1.3, -1.3}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+
+		result = RoundTo(Of(0.5, 2.5, -2.5), 0).Collect()
+		if expected := []float64{1, 3, -3}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Negative decimals round to tens", func(t *testing.T) {
+		result := RoundTo(Of(1234.0, 125.0, -125.0), -1).Collect()
+		if expected := []float64{1230, 130, -130}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Large magnitudes are unchanged", func(t *testing.T) {
+		data := []float64{1e300, -1e300, math.MaxFloat64, 1 << 53}
+		if result := RoundTo(NewFlow(data), 10).Collect(); !slices.Equal(result, data) {
+			t.Errorf("Expected %v, got %v", data, result)
+		}
+	})
+
+	t.Run("Large decimals keep finite values", func(t *testing.T) {
+		data := []float64{3.14159, -2.5, 0}
+		for _, decimals := range []int{20, 308, 309, 1000} {
+			if result := RoundTo(NewFlow(data), decimals).Collect(); !slices.Equal(result, data) {
+				t.Errorf("decimals=%d: expected %v, got %v", decimals, data, result)
+			}
+		}
+	})
+}
+
+func TestMapToMemo(t *testing.T) {
+	t.Run("Mapper called once per distinct input", func(t *testing.T) {
+		calls := 0
+		square := func(x int) int {
+			calls++
+			return x * x
+		}
+
+		result := MapToMemo(Of(2, 3, 2, 2, 3, 4), square).Collect()
+
+		expected := []int{4, 9, 4, 4, 9, 16}
+		for i := range expected {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %d, got %d", i, expected[i], result[i])
+			}
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 mapper calls, got %d", calls)
+		}
+	})
+
+	t.Run("Bounded cache evicts oldest entries", func(t *testing.T) {
+		calls := 0
+		identity := func(x int) int {
+			calls++
+			return x
+		}
+
+		// With room for two entries, 1 is evicted by 3 and must be recomputed.
+		MapToMemoBounded(Of(1, 2, 3, 1), identity, 2).Collect()
+
+		if calls != 4 {
+			t.Errorf("Expected 4 mapper calls, got %d", calls)
+		}
+	})
+}
+
+func TestMapBatch(t *testing.T) {
+	t.Run("Maps batches including the final partial one", func(t *testing.T) {
+		var sizes []int
+		result := MapBatch(Range(1, 8), 3, func(batch []int) []string {
+			sizes = append(sizes, len(batch))
+			out := make([]string, len(batch))
+			for i, v := range batch {
+				out[i] = strconv.Itoa(v * 10)
+			}
+			return out
+		}).Collect()
+
+		if !slices.Equal(sizes, []int{3, 3, 1}) {
+			t.Errorf("Expected batch sizes [3 3 1], got %v", sizes)
+		}
+		expected := []string{"10", "20", "30", "40", "50", "60", "70"}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Mapper may change the length", func(t *testing.T) {
+		sums := MapBatch(Range(1, 7), 2, func(batch []int) []int {
+			return []int{batch[0] + batch[1]}
+		}).Collect()
+
+		if !slices.Equal(sums, []int{3, 7, 11}) {
+			t.Errorf("Expected [3 7 11], got %v", sums)
+		}
+	})
+}
+
+func TestMapToBatched(t *testing.T) {
+	t.Run("Results follow input order", func(t *testing.T) {
+		var calls [][]int
+		result := MapToBatched(Range(1, 8), 3, func(ids []int) []string {
+			calls = append(calls, slices.Clone(ids))
+			out := make([]string, len(ids))
+			for i, id := range ids {
+				out[i] = "user" + strconv.Itoa(id)
+			}
+			return out
+		}).Collect()
+
+		expected := []string{"user1", "user2", "user3", "user4", "user5", "user6", "user7"}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		if expectedCalls := [][]int{{1, 2, 3}, {4, 5, 6}, {7}}; !slices.EqualFunc(calls, expectedCalls, slices.Equal) {
+			t.Errorf("Expected batches %v, got %v", expectedCalls, calls)
+		}
+	})
+
+	t.Run("Panics on mismatched output length", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Fatal("Expected panic for mismatched output length")
+			}
+			if msg, ok := r.(string); !ok || !strings.Contains(msg, "returned 1 results for 2 inputs") {
+				t.Errorf("Expected a message naming both lengths, got %v", r)
+			}
+		}()
+		MapToBatched(Range(0, 4), 2, func(batch []int) []int { return batch[:1] }).Collect()
+	})
+}
+
+func TestMapWithWindowStat(t *testing.T) {
+	t.Run("Mean matches a manual computation", func(t *testing.T) {
+		data := []int{4, 8, 6, 2, 10, 0, 3}
+		window := 3
+
+		var means []float64
+		values := MapWithWindowStat(NewFlow(data), window, func(v int, mean float64) int {
+			means = append(means, mean)
+			return v
+		}).Collect()
+
+		if !slices.Equal(values, data) {
+			t.Errorf("Expected values %v, got %v", data, values)
+		}
+		for i := range data {
+			start := max(0, i-window+1)
+			sum := 0
+			for _, v := range data[start : i+1] {
+				sum += v
+			}
+			expected := float64(sum) / float64(i+1-start)
+			if math.Abs(means[i]-expected) > 1e-9 {
+				t.Errorf("At index %d: expected mean %v, got %v", i, expected, means[i])
+			}
+		}
+	})
+
+	t.Run("Window of one is the element itself", func(t *testing.T) {
+		result := MapWithWindowStat(Of(1.5, -2.0, 7.25), 1, func(v, mean float64) bool { return v == mean }).Collect()
+
+		if !slices.Equal(result, []bool{true, true, true}) {
+			t.Errorf("Expected all true, got %v", result)
+		}
+	})
+
+	t.Run("NaN and Inf stop affecting the mean once evicted", func(t *testing.T) {
+		means := MapWithWindowStat(Of(math.NaN(), math.Inf(1), 2.0, 4.0), 2, func(_, mean float64) float64 {
+			return mean
+		}).Collect()
+
+		if !math.IsNaN(means[0]) || !math.IsNaN(means[1]) || !math.IsInf(means[2], 1) {
+			t.Errorf("Expected [NaN NaN +Inf 3] prefix, got %v", means)
+		}
+		if means[3] != 3 {
+			t.Errorf("Expected mean 3 after both evicted, got %v", means[3])
+		}
+	})
+
+	t.Run("Large magnitude does not lose later precision", func(t *testing.T) {
+		means := MapWithWindowStat(Of(1e16, 1.0, 1.0), 2, func(_, mean float64) float64 {
+			return mean
+		}).Collect()
+
+		if means[2] != 1 {
+			t.Errorf("Expected mean 1 after the large value is evicted, got %v", means[2])
+		}
+	})
+}
+
+func TestMapWithNext(t *testing.T) {
+	t.Run("Gaps between elements", func(t *testing.T) {
+		gaps := MapWithNext(Of(1, 4, 6, 10), func(cur int, next *int) int {
+			if next == nil {
+				return -1
+			}
+			return *next - cur
+		}).Collect()
+
+		expected := []int{3, 2, 4, -1}
+		if !slices.Equal(gaps, expected) {
+			t.Errorf("Expected %v, got %v", expected, gaps)
+		}
+	})
+
+	t.Run("Single element sees nil", func(t *testing.T) {
+		result := MapWithNext(Of("only"), func(cur string, next *string) bool { return next == nil }).Collect()
+		if len(result) != 1 || !result[0] {
+			t.Errorf("Expected [true], got %v", result)
+		}
+	})
+
+	t.Run("Lazy on infinite flows", func(t *testing.T) {
+		result := MapWithNext(Infinite(func(i int) int { return i }), func(cur int, next *int) int {
+			return cur + *next
+		}).Take(3).Collect()
+
+		if !slices.Equal(result, []int{1, 3, 5}) {
+			t.Errorf("Expected [1 3 5], got %v", result)
+		}
+	})
+}
+
+func TestMapToSwitch(t *testing.T) {
+	t.Run("Routes by selector", func(t *testing.T) {
+		result := MapToSwitch(Of(1, -2, 0, 3, -4),
+			func(x int) int {
+				switch {
+				case x > 0:
+					return 0
+				case x < 0:
+					return 1
+				default:
+					return 2
+				}
+			},
+			func(x int) string { return "pos " + strconv.Itoa(x) },
+			func(x int) string { return "neg " + strconv.Itoa(-x) },
+			func(int) string { return "zero" },
+		).Collect()
+
+		expected := []string{"pos 1", "neg 2", "zero", "pos 3", "neg 4"}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Panics on out-of-range selector", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Fatal("Expected panic for out-of-range selector")
+			}
+			if msg, ok := r.(string); !ok || !strings.Contains(msg, "selector returned 2") {
+				t.Errorf("Expected a message naming the bad index, got %v", r)
+			}
+		}()
+		MapToSwitch(Of(1), func(int) int { return 2 }, func(x int) int { return x }).Collect()
+	})
+}
+
 func TestChunk(t *testing.T) {
 	t.Run("Even chunks", func(t *testing.T) {
 		data := Range(1, 7)
@@ -94,6 +568,61 @@ func TestChunk(t *testing.T) {
 	})
 }
 
+func TestChunkPadded(t *testing.T) {
+	t.Run("Pads the final chunk", func(t *testing.T) {
+		chunks := ChunkPadded(Range(1, 8), 3, -1).Collect()
+
+		expected := [][]int{{1, 2, 3}, {4, 5, 6}, {7, -1, -1}}
+		if len(chunks) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, chunks)
+		}
+		for i := range chunks {
+			if !slices.Equal(chunks[i], expected[i]) {
+				t.Errorf("Chunk %d: expected %v, got %v", i, expected[i], chunks[i])
+			}
+		}
+	})
+
+	t.Run("No padding for exact multiples", func(t *testing.T) {
+		chunks := ChunkPadded(Range(0, 4), 2, -1).Collect()
+		if len(chunks) != 2 || !slices.Equal(chunks[1], []int{2, 3}) {
+			t.Errorf("Expected [[0 1] [2 3]], got %v", chunks)
+		}
+	})
+}
+
+func TestChunkByWeight(t *testing.T) {
+	identity := func(x int) int { return x }
+
+	t.Run("Respects the weight cap", func(t *testing.T) {
+		chunks := ChunkByWeight(Of(3, 4, 2, 5, 1, 1, 6), 7, identity).Collect()
+
+		expected := [][]int{{3, 4}, {2, 5}, {1, 1}, {6}}
+		if len(chunks) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, chunks)
+		}
+		for i := range chunks {
+			if !slices.Equal(chunks[i], expected[i]) {
+				t.Errorf("Chunk %d: expected %v, got %v", i, expected[i], chunks[i])
+			}
+		}
+	})
+
+	t.Run("Oversized element gets its own chunk", func(t *testing.T) {
+		chunks := ChunkByWeight(Of(1, 10, 1), 5, identity).Collect()
+
+		expected := [][]int{{1}, {10}, {1}}
+		if len(chunks) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, chunks)
+		}
+		for i := range chunks {
+			if !slices.Equal(chunks[i], expected[i]) {
+				t.Errorf("Chunk %d: expected %v, got %v", i, expected[i], chunks[i])
+			}
+		}
+	})
+}
+
 func TestGroupBy(t *testing.T) {
 	t.Run("Group by modulo", func(t *testing.T) {
 		data := Range(1, 11)
@@ -145,33 +674,113 @@ func TestPartition(t *testing.T) {
 	})
 }
 
-func TestWindow(t *testing.T) {
-	t.Run("Sliding window", func(t *testing.T) {
-		data := Range(1, 6)
-		result := Window(data, 3, 1).Collect()
+func TestHashPartition(t *testing.T) {
+	hash := func(x int) uint64 { return uint64(x * 7) }
 
-		expected := [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}
-		if len(result) != len(expected) {
-			t.Errorf("Expected %d windows, got %d", len(expected), len(result))
-		}
+	t.Run("Deterministic placement", func(t *testing.T) {
+		buckets := HashPartition(Range(0, 20), 3, hash)
 
-		for i, window := range result {
-			if len(window) != 3 {
-				t.Errorf("Window %d: expected size 3, got %d", i, len(window))
-			}
-			for j, v := range window {
-				if v != expected[i][j] {
-					t.Errorf("Window %d, index %d: expected %d, got %d", i, j, expected[i][j], v)
+		if len(buckets) != 3 {
+			t.Fatalf("Expected 3 buckets, got %d", len(buckets))
+		}
+		for i, bucket := range buckets {
+			for _, v := range bucket {
+				if hash(v)%3 != uint64(i) {
+					t.Errorf("Element %d placed in bucket %d", v, i)
 				}
 			}
 		}
+		if again := HashPartition(Range(0, 20), 3, hash); !slices.EqualFunc(buckets, again, slices.Equal) {
+			t.Errorf("Expected the same placement on every run, got %v and %v", buckets, again)
+		}
 	})
 
-	t.Run("Tumbling window", func(t *testing.T) {
-		data := Range(1, 10)
-		result := Window(data, 3, 3).Collect()
+	t.Run("Buckets hold all elements", func(t *testing.T) {
+		var all []int
+		for _, bucket := range HashPartition(Range(0, 20), 4, hash) {
+			all = append(all, bucket...)
+		}
+		slices.Sort(all)
 
-		expected := [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+		if expected := Range(0, 20).Collect(); !slices.Equal(all, expected) {
+			t.Errorf("Expected %v, got %v", expected, all)
+		}
+	})
+
+	t.Run("Panics on non-positive bucket count", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for zero buckets")
+			}
+		}()
+		HashPartition(Range(0, 3), 0, hash)
+	})
+}
+
+func TestBucketBy(t *testing.T) {
+	t.Run("Places elements by boundary range", func(t *testing.T) {
+		buckets := BucketBy(Of(-5.0, 0.0, 3.5, 10.0, 42.0, 99.9, 100.0, 250.0), []float64{0, 10, 100}, func(x float64) float64 { return x })
+
+		expected := [][]float64{{-5}, {0, 3.5}, {10, 42, 99.9}, {100, 250}}
+		if !slices.EqualFunc(buckets, expected, slices.Equal) {
+			t.Errorf("Expected %v, got %v", expected, buckets)
+		}
+	})
+
+	t.Run("No boundaries gives a single bucket", func(t *testing.T) {
+		buckets := BucketBy(Range(0, 3), nil, func(x int) float64 { return float64(x) })
+
+		if len(buckets) != 1 || !slices.Equal(buckets[0], []int{0, 1, 2}) {
+			t.Errorf("Expected [[0 1 2]], got %v", buckets)
+		}
+	})
+
+	t.Run("NaN values are dropped", func(t *testing.T) {
+		buckets := BucketBy(Of(5.0, math.NaN(), 50.0), []float64{10}, func(x float64) float64 { return x })
+
+		expected := [][]float64{{5}, {50}}
+		if !slices.EqualFunc(buckets, expected, slices.Equal) {
+			t.Errorf("Expected %v, got %v", expected, buckets)
+		}
+	})
+
+	t.Run("Panics on unsorted boundaries", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for unsorted boundaries")
+			}
+		}()
+		BucketBy(Of(1.0), []float64{10, 0}, func(x float64) float64 { return x })
+	})
+}
+
+func TestWindow(t *testing.T) {
+	t.Run("Sliding window", func(t *testing.T) {
+		data := Range(1, 6)
+		result := Window(data, 3, 1).Collect()
+
+		expected := [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}
+		if len(result) != len(expected) {
+			t.Errorf("Expected %d windows, got %d", len(expected), len(result))
+		}
+
+		for i, window := range result {
+			if len(window) != 3 {
+				t.Errorf("Window %d: expected size 3, got %d", i, len(window))
+			}
+			for j, v := range window {
+				if v != expected[i][j] {
+					t.Errorf("Window %d, index %d: expected %d, got %d", i, j, expected[i][j], v)
+				}
+			}
+		}
+	})
+
+	t.Run("Tumbling window", func(t *testing.T) {
+		data := Range(1, 10)
+		result := Window(data, 3, 3).Collect()
+
+		expected := [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
 		if len(result) != len(expected) {
 			t.Errorf("Expected %d windows, got %d", len(expected), len(result))
 		}
@@ -216,3 +825,579 @@ func TestFlatMap(t *testing.T) {
 		}
 	})
 }
+
+func TestFlatten(t *testing.T) {
+	t.Run("Concatenates inner flows in order", func(t *testing.T) {
+		result := Flatten(Of(Of(1, 2), Of(3, 4))).Collect()
+
+		if expected := []int{1, 2, 3, 4}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Empty inner flows are skipped", func(t *testing.T) {
+		result := Flatten(Of(Empty[string](), Of("a"), Empty[string](), Of("b", "c"))).Collect()
+
+		if expected := []string{"a", "b", "c"}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Early termination stops outer and inner flows", func(t *testing.T) {
+		outerPulled, innerPulled := 0, 0
+		nested := Infinite(func(i int) Flow[int, int] {
+			outerPulled++
+			return Infinite(func(j int) int {
+				innerPulled++
+				return i*10 + j
+			})
+		})
+
+		result := Flatten(nested).Take(3).Collect()
+
+		if expected := []int{0, 1, 2}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		if outerPulled != 1 || innerPulled != 3 {
+			t.Errorf("Expected 1 outer and 3 inner pulls, got %d and %d", outerPulled, innerPulled)
+		}
+	})
+}
+
+func TestFlattenSlice(t *testing.T) {
+	t.Run("Round-trips with Chunk", func(t *testing.T) {
+		original := Range(0, 11).Collect()
+		result := FlattenSlice(Chunk(NewFlow(original), 3)).Collect()
+
+		if !slices.Equal(result, original) {
+			t.Errorf("Expected %v, got %v", original, result)
+		}
+	})
+
+	t.Run("Empty inner slices are skipped", func(t *testing.T) {
+		result := FlattenSlice(Of([]string{"a"}, []string{}, nil, []string{"b", "c"})).Collect()
+
+		if expected := []string{"a", "b", "c"}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Lazy on infinite flows", func(t *testing.T) {
+		result := FlattenSlice(Infinite(func(i int) []int { return []int{i, i} })).Take(5).Collect()
+
+		if expected := []int{0, 0, 1, 1, 2}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+}
+
+func TestReduceSegments(t *testing.T) {
+	zero := func() int { return 0 }
+	sum := func(acc, x int) int { return acc + x }
+	isBoundary := func(x int) bool { return x == 0 }
+
+	t.Run("Sums reset at boundaries", func(t *testing.T) {
+		result := ReduceSegments(Of(1, 2, 0, 3, 4, 0, 5), zero, sum, isBoundary).Collect()
+
+		expected := []int{3, 7, 5}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i, v := range result {
+			if v != expected[i] {
+				t.Errorf("At index %d: expected %d, got %d", i, expected[i], v)
+			}
+		}
+	})
+
+	t.Run("No trailing segment after final boundary", func(t *testing.T) {
+		result := ReduceSegments(Of(1, 0, 2, 0), zero, sum, isBoundary).Collect()
+
+		if len(result) != 2 || result[0] != 1 || result[1] != 2 {
+			t.Errorf("Expected [1 2], got %v", result)
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		result := ReduceSegments(Empty[int](), zero, sum, isBoundary).Collect()
+		if len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+}
+
+func TestSorted(t *testing.T) {
+	t.Run("Ascending order", func(t *testing.T) {
+		data := []int{5, 2, 9, 1, 5, 3}
+		result := Sorted(NewFlow(data)).Collect()
+
+		if expected := []int{1, 2, 3, 5, 5, 9}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		if !slices.Equal(data, []int{5, 2, 9, 1, 5, 3}) {
+			t.Errorf("Expected the source slice to stay unsorted, got %v", data)
+		}
+	})
+
+	t.Run("Strings", func(t *testing.T) {
+		result := Sorted(Of("pear", "apple", "fig")).Collect()
+
+		if expected := []string{"apple", "fig", "pear"}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("SortedFunc is stable", func(t *testing.T) {
+		people := Of(person{"Alice", 30}, person{"Bob", 25}, person{"Carol", 30}, person{"Dan", 25})
+
+		result := SortedFunc(people, func(a, b person) int { return a.Age - b.Age }).Collect()
+
+		var names []string
+		for _, p := range result {
+			names = append(names, p.Name)
+		}
+		if expected := []string{"Bob", "Dan", "Alice", "Carol"}; !slices.Equal(names, expected) {
+			t.Errorf("Expected %v, got %v", expected, names)
+		}
+	})
+}
+
+func TestCompactSorted(t *testing.T) {
+	type reading struct {
+		Timestamp int
+		Value     string
+	}
+
+	t.Run("Sorts by key and later duplicates win", func(t *testing.T) {
+		data := NewFlow([]reading{
+			{3, "c1"},
+			{1, "a1"},
+			{2, "b1"},
+			{1, "a2"},
+			{3, "c2"},
+		})
+		result := CompactSorted(data, func(r reading) int { return r.Timestamp })
+
+		expected := []reading{{1, "a2"}, {2, "b1"}, {3, "c2"}}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %v, got %v", i, expected[i], result[i])
+			}
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		result := CompactSorted(Empty[int](), func(x int) int { return x })
+		if len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+}
+
+func TestFilterE(t *testing.T) {
+	errOdd := errors.New("odd value")
+
+	t.Run("Matches without error", func(t *testing.T) {
+		matches, errFn := FilterE(Range(1, 7), func(x int) (bool, error) {
+			return x%2 == 0, nil
+		})
+		result := matches.Collect()
+
+		if len(result) != 3 || result[0] != 2 || result[2] != 6 {
+			t.Errorf("Expected [2 4 6], got %v", result)
+		}
+		if err := errFn(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Predicate error surfaces and stops the flow", func(t *testing.T) {
+		matches, errFn := FilterE(Of(2, 4, 5, 6), func(x int) (bool, error) {
+			if x%2 != 0 {
+				return false, errOdd
+			}
+			return true, nil
+		})
+		result := matches.Collect()
+
+		if len(result) != 2 {
+			t.Errorf("Expected elements before the error only, got %v", result)
+		}
+		if err := errFn(); !errors.Is(err, errOdd) {
+			t.Errorf("Expected %v, got %v", errOdd, err)
+		}
+	})
+}
+
+func TestPartitionMap(t *testing.T) {
+	t.Run("Routes into typed slices", func(t *testing.T) {
+		numbers, words := PartitionMap(Of("1", "two", "3", "four"), func(s string) (int, string, bool) {
+			n, err := strconv.Atoi(s)
+			return n, strings.ToUpper(s), err == nil
+		})
+
+		if len(numbers) != 2 || numbers[0] != 1 || numbers[1] != 3 {
+			t.Errorf("Expected numbers [1 3], got %v", numbers)
+		}
+		if len(words) != 2 || words[0] != "TWO" || words[1] != "FOUR" {
+			t.Errorf("Expected words [TWO FOUR], got %v", words)
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		lefts, rights := PartitionMap(Empty[int](), func(x int) (int, int, bool) { return x, x, true })
+		if len(lefts) != 0 || len(rights) != 0 {
+			t.Errorf("Expected empty results, got %v and %v", lefts, rights)
+		}
+	})
+}
+
+func TestTakeEvery(t *testing.T) {
+	t.Run("Samples every k-th position", func(t *testing.T) {
+		result := TakeEvery(Range(0, 10), 3).Collect()
+
+		expected := []int{0, 3, 6, 9}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %d, got %d", i, expected[i], result[i])
+			}
+		}
+	})
+
+	t.Run("k of one keeps everything", func(t *testing.T) {
+		if count := TakeEvery(Range(0, 5), 1).Count(); count != 5 {
+			t.Errorf("Expected 5 elements, got %d", count)
+		}
+	})
+
+	t.Run("Panics on non-positive k", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for k = 0")
+			}
+		}()
+		TakeEvery(Range(0, 5), 0)
+	})
+}
+
+func TestTakeWhileSum(t *testing.T) {
+	t.Run("Stops before exceeding the budget", func(t *testing.T) {
+		result := TakeWhileSum(Of(4, 3, 2, 5, 1), 10).Collect()
+
+		if expected := []int{4, 3, 2}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Exact budget is included", func(t *testing.T) {
+		result := TakeWhileSum(Of(2.5, 2.5, 5.0, 0.1), 10.0).Collect()
+
+		if expected := []float64{2.5, 2.5, 5.0}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("First element over budget yields nothing", func(t *testing.T) {
+		if count := TakeWhileSum(Of(11, 1), 10).Count(); count != 0 {
+			t.Errorf("Expected no elements, got %d", count)
+		}
+	})
+
+	t.Run("Lazy on infinite flows", func(t *testing.T) {
+		result := TakeWhileSum(Infinite(func(i int) int { return 1 }), 3).Collect()
+
+		if len(result) != 3 {
+			t.Errorf("Expected 3 elements, got %v", result)
+		}
+	})
+}
+
+func TestCollectString(t *testing.T) {
+	t.Run("Round-trips runes including multibyte", func(t *testing.T) {
+		for _, s := range []string{"", "hello", "héllo wörld", "日本語 🎉"} {
+			if result := CollectString(FromRunes(s)); result != s {
+				t.Errorf("Expected %q, got %q", s, result)
+			}
+		}
+	})
+
+	t.Run("Bytes", func(t *testing.T) {
+		data := NewFlow([]byte("héllo"))
+		if result := CollectStringBytes(data); result != "héllo" {
+			t.Errorf("Expected %q, got %q", "héllo", result)
+		}
+	})
+
+	t.Run("After transformation", func(t *testing.T) {
+		result := CollectString(MapTo(FromRunes("abc"), func(r rune) rune { return r - 32 }))
+		if result != "ABC" {
+			t.Errorf("Expected %q, got %q", "ABC", result)
+		}
+	})
+}
+
+func TestWindowCounts(t *testing.T) {
+	t.Run("Last window may be smaller", func(t *testing.T) {
+		result := WindowCounts(Range(0, 11), 4).Collect()
+
+		expected := []int{4, 4, 3}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %d, got %d", i, expected[i], result[i])
+			}
+		}
+	})
+
+	t.Run("Exact multiple", func(t *testing.T) {
+		result := WindowCounts(Range(0, 6), 3).Collect()
+		if len(result) != 2 || result[0] != 3 || result[1] != 3 {
+			t.Errorf("Expected [3 3], got %v", result)
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		if result := WindowCounts(Empty[int](), 3).Collect(); len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+}
+
+func TestWindowByTrigger(t *testing.T) {
+	t.Run("Closes on a sentinel element", func(t *testing.T) {
+		lines := Of("BEGIN", "a", "b", "BEGIN", "c", "BEGIN", "d", "e")
+		records := WindowByTrigger(lines, func(_ []string, next string) bool { return next == "BEGIN" }).Collect()
+
+		expected := [][]string{{"BEGIN", "a", "b"}, {"BEGIN", "c"}, {"BEGIN", "d", "e"}}
+		if !slices.EqualFunc(records, expected, slices.Equal) {
+			t.Errorf("Expected %v, got %v", expected, records)
+		}
+	})
+
+	t.Run("Trigger can inspect the current window", func(t *testing.T) {
+		// Close once the window sum would exceed 10
+		windows := WindowByTrigger(Of(4, 5, 3, 9, 1, 1), func(current []int, next int) bool {
+			sum := next
+			for _, v := range current {
+				sum += v
+			}
+			return sum > 10
+		}).Collect()
+
+		expected := [][]int{{4, 5}, {3}, {9, 1}, {1}}
+		if !slices.EqualFunc(windows, expected, slices.Equal) {
+			t.Errorf("Expected %v, got %v", expected, windows)
+		}
+	})
+
+	t.Run("Never triggered yields one window", func(t *testing.T) {
+		windows := WindowByTrigger(Range(0, 4), func([]int, int) bool { return false }).Collect()
+
+		if len(windows) != 1 || !slices.Equal(windows[0], []int{0, 1, 2, 3}) {
+			t.Errorf("Expected [[0 1 2 3]], got %v", windows)
+		}
+	})
+
+	t.Run("Empty flow yields nothing", func(t *testing.T) {
+		if count := WindowByTrigger(Empty[int](), func([]int, int) bool { return true }).Count(); count != 0 {
+			t.Errorf("Expected no windows, got %d", count)
+		}
+	})
+}
+
+func TestRepeatEach(t *testing.T) {
+	t.Run("Repeats consecutively", func(t *testing.T) {
+		result := RepeatEach(Of("a", "b"), 3).Collect()
+
+		expected := []string{"a", "a", "a", "b", "b", "b"}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %s, got %s", i, expected[i], result[i])
+			}
+		}
+	})
+
+	t.Run("Non-positive n yields nothing", func(t *testing.T) {
+		if count := RepeatEach(Of(1, 2), 0).Count(); count != 0 {
+			t.Errorf("Expected 0 elements, got %d", count)
+		}
+		if count := RepeatEach(Of(1, 2), -1).Count(); count != 0 {
+			t.Errorf("Expected 0 elements, got %d", count)
+		}
+	})
+
+	t.Run("Lazy with Take", func(t *testing.T) {
+		result := RepeatEach(Infinite(func(i int) int { return i }), 2).Take(3).Collect()
+		if len(result) != 3 || result[2] != 1 {
+			t.Errorf("Expected [0 0 1], got %v", result)
+		}
+	})
+}
+
+func TestRepeatEachBy(t *testing.T) {
+	t.Run("Counts per element", func(t *testing.T) {
+		counts := map[string]int{"a": 3, "b": 0, "c": 1}
+		result := RepeatEachBy(Of("a", "b", "c"), func(s string) int { return counts[s] }).Collect()
+
+		expected := []string{"a", "a", "a", "c"}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %s, got %s", i, expected[i], result[i])
+			}
+		}
+	})
+
+	t.Run("Negative count drops element", func(t *testing.T) {
+		result := RepeatEachBy(Of(-2, 2), func(x int) int { return x }).Collect()
+		if len(result) != 2 || result[0] != 2 || result[1] != 2 {
+			t.Errorf("Expected [2 2], got %v", result)
+		}
+	})
+}
+
+func TestScan(t *testing.T) {
+	add := func(acc, x int) int { return acc + x }
+
+	t.Run("Prefix sums without the initial value", func(t *testing.T) {
+		result := Scan(Range(1, 5), 0, add).Collect()
+
+		if expected := []int{1, 3, 6, 10}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Accumulator of a different type", func(t *testing.T) {
+		result := Scan(Of("a", "b", "c"), "", func(acc, s string) string { return acc + s }).Collect()
+
+		if expected := []string{"a", "ab", "abc"}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Lazy on infinite flows", func(t *testing.T) {
+		result := Scan(Infinite(func(i int) int { return i + 1 }), 0, add).Take(4).Collect()
+
+		if expected := []int{1, 3, 6, 10}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Empty flow yields nothing", func(t *testing.T) {
+		if count := Scan(Empty[int](), 100, add).Count(); count != 0 {
+			t.Errorf("Expected no elements, got %d", count)
+		}
+	})
+}
+
+func TestScanWhile(t *testing.T) {
+	t.Run("Stops after the signaling element", func(t *testing.T) {
+		consumed := 0
+		source := Infinite(func(i int) int { return i + 1 }).Peek(func(int) { consumed++ })
+
+		result := ScanWhile(source, 0, func(acc, x int) (int, bool) {
+			acc += x
+			return acc, acc < 10
+		}).Collect()
+
+		expected := []int{1, 3, 6, 10}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		if consumed != 4 {
+			t.Errorf("Expected 4 elements consumed, got %d", consumed)
+		}
+	})
+
+	t.Run("Runs to completion without stop", func(t *testing.T) {
+		result := ScanWhile(Of("a", "b"), "", func(acc, s string) (string, bool) {
+			return acc + s, true
+		}).Collect()
+
+		if !slices.Equal(result, []string{"a", "ab"}) {
+			t.Errorf("Expected [a ab], got %v", result)
+		}
+	})
+}
+
+func TestMergeIntervals(t *testing.T) {
+	t.Run("Merges overlapping intervals", func(t *testing.T) {
+		result := MergeIntervals(NewFlow([]Interval{{Start: 1, End: 3}, {Start: 2, End: 5}, {Start: 7, End: 9}})).Collect()
+
+		expected := []Interval{{Start: 1, End: 5}, {Start: 7, End: 9}}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Touching and contained intervals", func(t *testing.T) {
+		result := MergeIntervals(NewFlow([]Interval{
+			{Start: 1, End: 4},
+			{Start: 2, End: 3},
+			{Start: 4, End: 6},
+			{Start: 8, End: 8},
+		})).Collect()
+
+		expected := []Interval{{Start: 1, End: 6}, {Start: 8, End: 8}}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Adjacent intervals merge", func(t *testing.T) {
+		result := MergeIntervals(NewFlow([]Interval{
+			{Start: 1, End: 3},
+			{Start: 4, End: 5},
+			{Start: 7, End: 8},
+			{Start: 10, End: math.MaxInt},
+			{Start: math.MaxInt, End: math.MaxInt},
+		})).Collect()
+
+		expected := []Interval{{Start: 1, End: 5}, {Start: 7, End: 8}, {Start: 10, End: math.MaxInt}}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		if result := MergeIntervals(Empty[Interval]()).Collect(); len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+}
+
+func TestMapMany(t *testing.T) {
+	t.Run("Expands and contracts", func(t *testing.T) {
+		result := MapMany(Of(0, 1, 3), func(n int) []string {
+			return slices.Repeat([]string{strconv.Itoa(n)}, n)
+		}).Collect()
+
+		expected := []string{"1", "3", "3", "3"}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Stops early", func(t *testing.T) {
+		result := MapMany(Infinite(func(i int) int { return i }), func(n int) []int {
+			return []int{n, n}
+		}).Take(3).Collect()
+
+		if !slices.Equal(result, []int{0, 0, 1}) {
+			t.Errorf("Expected [0 0 1], got %v", result)
+		}
+	})
+}
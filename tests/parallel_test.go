@@ -0,0 +1,271 @@
+package flow_test
+
+import (
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/MirrexOne/Flow"
+)
+
+func TestParallelReduce(t *testing.T) {
+	t.Run("Sum matches serial Reduce", func(t *testing.T) {
+		data := Range(0, 10_000)
+		serial := data.Reduce(0, func(acc, x int) int { return acc + x })
+		parallel := ParallelReduce(data, 4,
+			func() int { return 0 },
+			func(acc, x int) int { return acc + x },
+			func(a, b int) int { return a + b },
+		)
+
+		if parallel != serial {
+			t.Errorf("Expected %d, got %d", serial, parallel)
+		}
+	})
+
+	t.Run("Associative concatenation keeps order", func(t *testing.T) {
+		data := MapTo(Range(0, 2000), strconv.Itoa)
+
+		var expected strings.Builder
+		data.ForEachFunc(func(s string) { expected.WriteString(s) })
+
+		parallel := ParallelReduce(data, 3,
+			func() string { return "" },
+			func(acc, s string) string { return acc + s },
+			func(a, b string) string { return a + b },
+		)
+
+		if parallel != expected.String() {
+			t.Errorf("Expected ordered concatenation of length %d, got length %d", expected.Len(), len(parallel))
+		}
+	})
+
+	t.Run("Empty flow returns initial", func(t *testing.T) {
+		result := ParallelReduce(Empty[int](), 2,
+			func() int { return 0 },
+			func(acc, x int) int { return acc + x },
+			func(a, b int) int { return a + b },
+		)
+
+		if result != 0 {
+			t.Errorf("Expected 0, got %d", result)
+		}
+	})
+}
+
+func TestPipeline(t *testing.T) {
+	double := func(x int) int { return x * 2 }
+	inc := func(x int) int { return x + 1 }
+
+	t.Run("Matches sequential application in order", func(t *testing.T) {
+		result := NewPipeline[int](2).AddStage(double).AddStage(inc).Run(Range(0, 1000)).Collect()
+
+		if len(result) != 1000 {
+			t.Fatalf("Expected 1000 elements, got %d", len(result))
+		}
+		for i, v := range result {
+			if expected := inc(double(i)); v != expected {
+				t.Fatalf("At index %d: expected %d, got %d", i, expected, v)
+			}
+		}
+	})
+
+	t.Run("No stages passes elements through", func(t *testing.T) {
+		result := NewPipeline[int](0).Run(Of(1, 2, 3)).Collect()
+		if len(result) != 3 || result[2] != 3 {
+			t.Errorf("Expected [1 2 3], got %v", result)
+		}
+	})
+
+	t.Run("Stops goroutines on early termination", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+
+		p := NewPipeline[int](4).AddStage(double).AddStage(inc).AddStage(double)
+		result := p.Run(Infinite(func(i int) int { return i })).Take(5).Collect()
+		if len(result) != 5 {
+			t.Fatalf("Expected 5 elements, got %v", result)
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if after := runtime.NumGoroutine(); after > before {
+			t.Errorf("Expected goroutines to stop, had %d before and %d after", before, after)
+		}
+	})
+}
+
+func TestTreeReduce(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+
+	t.Run("Sum matches serial Reduce", func(t *testing.T) {
+		data := Range(0, 10_001)
+		serial := data.Reduce(0, add)
+
+		for _, workers := range []int{1, 3, 4, 16} {
+			if result := TreeReduce(data, workers, 0, add); result != serial {
+				t.Errorf("workers=%d: expected %d, got %d", workers, serial, result)
+			}
+		}
+	})
+
+	t.Run("Non-commutative operation keeps order", func(t *testing.T) {
+		data := MapTo(Range(0, 500), strconv.Itoa)
+		expected := data.Reduce("", func(a, b string) string { return a + b })
+
+		result := TreeReduce(data, 5, "", func(a, b string) string { return a + b })
+		if result != expected {
+			t.Errorf("Expected ordered concatenation of length %d, got length %d", len(expected), len(result))
+		}
+	})
+
+	t.Run("Fewer elements than workers", func(t *testing.T) {
+		if result := TreeReduce(Of(1, 2), 8, 0, add); result != 3 {
+			t.Errorf("Expected 3, got %d", result)
+		}
+	})
+
+	t.Run("Empty flow returns identity", func(t *testing.T) {
+		if result := TreeReduce(Empty[int](), 4, 1, func(a, b int) int { return a * b }); result != 1 {
+			t.Errorf("Expected identity 1, got %d", result)
+		}
+	})
+}
+
+func TestTakeShared(t *testing.T) {
+	t.Run("Single consumer stops at the budget", func(t *testing.T) {
+		budget := int64(5)
+		result := TakeShared(Range(0, 100), &budget).Collect()
+
+		if len(result) != 5 {
+			t.Errorf("Expected 5 elements, got %v", result)
+		}
+		if budget != 0 {
+			t.Errorf("Expected the budget to be used up, got %d", budget)
+		}
+	})
+
+	t.Run("Consumers share one total", func(t *testing.T) {
+		source := make(chan int)
+		go func() {
+			defer close(source)
+			for i := range 10_000 {
+				source <- i
+			}
+		}()
+
+		budget := int64(500)
+		counts := make([]int, 2)
+		var wg sync.WaitGroup
+		for i := range counts {
+			wg.Go(func() {
+				counts[i] = TakeShared(FromChannel(source), &budget).Count()
+			})
+		}
+		wg.Wait()
+		// Drain the producer so it can exit
+		for range source {
+		}
+
+		if total := counts[0] + counts[1]; total != 500 {
+			t.Errorf("Expected 500 elements in total, got %d (%v)", total, counts)
+		}
+		if budget != 0 {
+			t.Errorf("Expected the budget to be used up, got %d", budget)
+		}
+	})
+
+	t.Run("Shared channel loses no elements", func(t *testing.T) {
+		for range 200 {
+			jobs := make(chan int, 20)
+			for i := range 20 {
+				jobs <- i
+			}
+			close(jobs)
+
+			budget := int64(3)
+			received := make([][]int, 4)
+			var wg sync.WaitGroup
+			for i := range received {
+				wg.Go(func() {
+					// Yield the processor after every pull to widen any pull-then-reserve window
+					source := FromChannel(jobs).Peek(func(int) { runtime.Gosched() })
+					received[i] = TakeShared(source, &budget).Collect()
+				})
+			}
+			wg.Wait()
+
+			var all []int
+			for _, r := range received {
+				all = append(all, r...)
+			}
+			if len(all) != 3 {
+				t.Fatalf("Expected 3 elements in total, got %v", received)
+			}
+			for v := range jobs {
+				all = append(all, v)
+			}
+			slices.Sort(all)
+			if expected := Range(0, 20).Collect(); !slices.Equal(all, expected) {
+				t.Fatalf("Expected every element to be yielded or left in the channel, got %v", all)
+			}
+		}
+	})
+
+	t.Run("Unit returned when the source ends", func(t *testing.T) {
+		budget := int64(10)
+		if count := TakeShared(Range(0, 3), &budget).Count(); count != 3 {
+			t.Errorf("Expected 3 elements, got %d", count)
+		}
+		if budget != 7 {
+			t.Errorf("Expected 7 units left, got %d", budget)
+		}
+	})
+
+	t.Run("Exhausted budget pulls nothing", func(t *testing.T) {
+		budget := int64(0)
+		pulled := 0
+		TakeShared(Range(0, 10).Peek(func(int) { pulled++ }), &budget).Collect()
+
+		if pulled != 0 {
+			t.Errorf("Expected no elements pulled, got %d", pulled)
+		}
+	})
+}
+
+func TestParallelGroupBy(t *testing.T) {
+	t.Run("Matches serial GroupBy", func(t *testing.T) {
+		data := Range(0, 10_000)
+		mod := func(x int) int { return x % 7 }
+
+		serial := GroupBy(data, mod)
+		parallel := ParallelGroupBy(data, 4, mod)
+
+		if len(parallel) != len(serial) {
+			t.Fatalf("Expected %d groups, got %d", len(serial), len(parallel))
+		}
+		for key, values := range serial {
+			if len(parallel[key]) != len(values) {
+				t.Errorf("Group %d: expected %d elements, got %d", key, len(values), len(parallel[key]))
+				continue
+			}
+			for i := range values {
+				if parallel[key][i] != values[i] {
+					t.Errorf("Group %d: order differs at index %d", key, i)
+					break
+				}
+			}
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		if groups := ParallelGroupBy(Empty[int](), 2, func(x int) int { return x }); len(groups) != 0 {
+			t.Errorf("Expected no groups, got %v", groups)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package flow_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	. "github.com/MirrexOne/Flow"
+)
+
+func TestSplitResults(t *testing.T) {
+	t.Run("Mixed successes and errors", func(t *testing.T) {
+		results := MapTo(Of("1", "x", "3", "y"), func(s string) Result[int] {
+			n, err := strconv.Atoi(s)
+			return Result[int]{Value: n, Err: err}
+		})
+
+		values, errs := SplitResults(results)
+
+		if len(values) != 2 || values[0] != 1 || values[1] != 3 {
+			t.Errorf("Expected values [1 3], got %v", values)
+		}
+		if len(errs) != 2 {
+			t.Fatalf("Expected 2 errors, got %v", errs)
+		}
+		for _, err := range errs {
+			if _, ok := err.(*strconv.NumError); !ok {
+				t.Errorf("Expected *strconv.NumError, got %T", err)
+			}
+		}
+	})
+
+	t.Run("Empty flow", func(t *testing.T) {
+		values, errs := SplitResults(Empty[Result[int]]())
+		if len(values) != 0 || len(errs) != 0 {
+			t.Errorf("Expected empty results, got %v and %v", values, errs)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	errNegative := errors.New("negative value")
+	nonNegative := func(x int) error {
+		if x < 0 {
+			return errNegative
+		}
+		return nil
+	}
+
+	t.Run("Reports the first failing element", func(t *testing.T) {
+		checked := 0
+		i, err := Validate(Of(3, 0, -1, 5, -2).Peek(func(int) { checked++ }), nonNegative)
+
+		if i != 2 || !errors.Is(err, errNegative) {
+			t.Errorf("Expected (2, %v), got (%d, %v)", errNegative, i, err)
+		}
+		if checked != 3 {
+			t.Errorf("Expected to stop after 3 elements, checked %d", checked)
+		}
+	})
+
+	t.Run("All valid", func(t *testing.T) {
+		if i, err := Validate(Range(0, 10), nonNegative); i != -1 || err != nil {
+			t.Errorf("Expected (-1, nil), got (%d, %v)", i, err)
+		}
+	})
+}
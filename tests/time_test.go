@@ -0,0 +1,498 @@
+package flow_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/MirrexOne/Flow"
+	"github.com/MirrexOne/Flow/internal"
+)
+
+var clockStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestTick(t *testing.T) {
+	t.Run("Yields spaced timestamps", func(t *testing.T) {
+		interval := 5 * time.Millisecond
+		start := time.Now()
+		ticks := Tick(interval).Take(3).Collect()
+
+		if len(ticks) != 3 {
+			t.Fatalf("Expected 3 ticks, got %d", len(ticks))
+		}
+		if ticks[0].Before(start) {
+			t.Errorf("First tick %v precedes start %v", ticks[0], start)
+		}
+		for i := 1; i < len(ticks); i++ {
+			if gap := ticks[i].Sub(ticks[i-1]); gap < interval/2 {
+				t.Errorf("Ticks %d and %d are only %v apart", i-1, i, gap)
+			}
+		}
+	})
+
+	t.Run("Stops ticking when consumer stops", func(t *testing.T) {
+		clock := internal.NewFakeClock(clockStart)
+		done := make(chan []time.Time)
+		go func() {
+			done <- Tick(time.Second, WithClock(clock)).Take(1).Collect()
+		}()
+
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+		if ticks := <-done; len(ticks) != 1 {
+			t.Fatalf("Expected 1 tick, got %v", ticks)
+		}
+		if pending := clock.Waiters(); pending != 0 {
+			t.Errorf("Expected the ticker to be stopped, %d still registered", pending)
+		}
+	})
+
+	t.Run("Panics on non-positive interval", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for zero interval")
+			}
+		}()
+		Tick(0)
+	})
+
+	t.Run("Fake clock drives ticks", func(t *testing.T) {
+		clock := internal.NewFakeClock(clockStart)
+		ticks := Tick(time.Second, WithClock(clock)).Take(3).ToChannel(0)
+
+		clock.BlockUntil(1)
+		for i := 1; i <= 3; i++ {
+			clock.Advance(time.Second)
+			tick := <-ticks
+			if expected := clockStart.Add(time.Duration(i) * time.Second); !tick.Equal(expected) {
+				t.Errorf("Expected %v, got %v", expected, tick)
+			}
+		}
+		if _, ok := <-ticks; ok {
+			t.Error("Expected the flow to end after 3 ticks")
+		}
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Run("Grows exponentially up to the cap", func(t *testing.T) {
+		result := Backoff(10*time.Millisecond, 2, 50*time.Millisecond).Take(6).Collect()
+
+		expected := []time.Duration{
+			10 * time.Millisecond,
+			20 * time.Millisecond,
+			40 * time.Millisecond,
+			50 * time.Millisecond,
+			50 * time.Millisecond,
+			50 * time.Millisecond,
+		}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %v, got %v", i, expected[i], result[i])
+			}
+		}
+	})
+
+	t.Run("Panics on a factor below 1", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for factor 0.5")
+			}
+		}()
+		Backoff(time.Second, 0.5, time.Minute)
+	})
+
+	t.Run("Panics on a NaN factor", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for NaN factor")
+			}
+		}()
+		Backoff(time.Second, math.NaN(), time.Minute)
+	})
+
+	t.Run("Panics when max is below base", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for max below base")
+			}
+		}()
+		Backoff(time.Second, 2, time.Millisecond)
+	})
+
+	t.Run("Fractional factor", func(t *testing.T) {
+		result := Backoff(time.Second, 1.5, time.Minute).Take(3).Collect()
+
+		expected := []time.Duration{time.Second, 1500 * time.Millisecond, 2250 * time.Millisecond}
+		for i := range expected {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %v, got %v", i, expected[i], result[i])
+			}
+		}
+	})
+}
+
+// liveSource returns a channel that receives increasing integers every interval
+// until stop is closed.
+func liveSource(interval time.Duration, stop <-chan struct{}) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 0; ; i++ {
+			select {
+			case ch <- i:
+			case <-stop:
+				return
+			}
+			time.Sleep(interval)
+		}
+	}()
+	return ch
+}
+
+func TestTakeFor(t *testing.T) {
+	t.Run("Stops near the deadline", func(t *testing.T) {
+		stop := make(chan struct{})
+		defer close(stop)
+
+		start := time.Now()
+		result := TakeFor(FromChannel(liveSource(time.Millisecond, stop)), 30*time.Millisecond).Collect()
+		elapsed := time.Since(start)
+
+		if len(result) == 0 {
+			t.Error("Expected some elements before the deadline")
+		}
+		if elapsed < 30*time.Millisecond || elapsed > 500*time.Millisecond {
+			t.Errorf("Expected to stop near 30ms, took %v", elapsed)
+		}
+		for i := range result {
+			if result[i] != i {
+				t.Fatalf("Expected sequential elements, got %v", result)
+			}
+		}
+	})
+
+	t.Run("Stops even if the source is idle", func(t *testing.T) {
+		idle := make(chan int)
+		defer close(idle)
+
+		start := time.Now()
+		result := TakeFor(FromChannel(idle), 20*time.Millisecond).Collect()
+
+		if len(result) != 0 {
+			t.Errorf("Expected no elements, got %v", result)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Expected to stop near 20ms, took %v", elapsed)
+		}
+	})
+
+	t.Run("Finite source ends first", func(t *testing.T) {
+		if count := TakeFor(Range(0, 5), time.Minute).Count(); count != 5 {
+			t.Errorf("Expected 5 elements, got %d", count)
+		}
+	})
+
+	t.Run("Fake clock ends the flow", func(t *testing.T) {
+		clock := internal.NewFakeClock(clockStart)
+		source := make(chan int)
+		defer close(source)
+		out := TakeFor(FromChannel(source), 10*time.Second, WithClock(clock)).ToChannel(0)
+
+		clock.BlockUntil(1)
+		for i := range 3 {
+			source <- i
+			if v := <-out; v != i {
+				t.Errorf("Expected %d, got %d", i, v)
+			}
+		}
+		clock.Advance(9 * time.Second)
+		source <- 3
+		if v := <-out; v != 3 {
+			t.Errorf("Expected 3, got %d", v)
+		}
+		clock.Advance(time.Second)
+		if v, ok := <-out; ok {
+			t.Errorf("Expected the flow to end at the deadline, got %d", v)
+		}
+	})
+}
+
+func TestSkipFor(t *testing.T) {
+	t.Run("Drops early elements and passes later ones", func(t *testing.T) {
+		stop := make(chan struct{})
+		defer close(stop)
+
+		start := time.Now()
+		var firstAt time.Duration
+		result := SkipFor(FromChannel(liveSource(time.Millisecond, stop)), 20*time.Millisecond).
+			BeforeFirst(func() { firstAt = time.Since(start) }).
+			Take(5).
+			Collect()
+
+		if firstAt < 20*time.Millisecond {
+			t.Errorf("Expected first element after 20ms, got it after %v", firstAt)
+		}
+		if len(result) != 5 || result[0] == 0 {
+			t.Fatalf("Expected 5 elements after the warm-up, got %v", result)
+		}
+		for i := 1; i < len(result); i++ {
+			if result[i] != result[i-1]+1 {
+				t.Errorf("Expected consecutive elements after the warm-up, got %v", result)
+			}
+		}
+	})
+
+	t.Run("Zero duration skips nothing", func(t *testing.T) {
+		if count := SkipFor(Range(0, 5), 0).Count(); count != 5 {
+			t.Errorf("Expected 5 elements, got %d", count)
+		}
+	})
+
+	t.Run("Fake clock", func(t *testing.T) {
+		clock := internal.NewFakeClock(clockStart)
+		readings := FromFunc(func(yield func(int, int) bool) {
+			for i := range 5 {
+				if !yield(i, i) {
+					return
+				}
+				clock.Advance(4 * time.Second)
+			}
+		})
+
+		result := SkipFor(readings, 10*time.Second, WithClock(clock)).Collect()
+
+		// Elements arrive at 0s, 4s, 8s, 12s and 16s
+		expected := []int{3, 4}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+}
+
+func TestDistinctTTL(t *testing.T) {
+	t.Run("Suppressed within ttl, re-emitted after", func(t *testing.T) {
+		ttl := 20 * time.Millisecond
+		events := FromFunc(func(yield func(string, string) bool) {
+			for _, step := range []struct {
+				value string
+				wait  time.Duration
+			}{
+				{"a", 0},
+				{"b", 0},
+				{"a", 0},
+				{"a", 2 * ttl},
+				{"b", 0},
+			} {
+				time.Sleep(step.wait)
+				if !yield(step.value, step.value) {
+					return
+				}
+			}
+		})
+
+		result := DistinctTTL(events, ttl).Collect()
+
+		expected := []string{"a", "b", "a", "b"}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i := range result {
+			if result[i] != expected[i] {
+				t.Errorf("At index %d: expected %s, got %s", i, expected[i], result[i])
+			}
+		}
+	})
+
+	t.Run("Fake clock", func(t *testing.T) {
+		clock := internal.NewFakeClock(clockStart)
+		events := FromFunc(func(yield func(string, string) bool) {
+			for _, step := range []struct {
+				value string
+				wait  time.Duration
+			}{
+				{"a", 0},
+				{"a", 9 * time.Second},
+				{"b", 0},
+				{"a", time.Second},
+				{"b", 5 * time.Second},
+				{"b", 5 * time.Second},
+			} {
+				clock.Advance(step.wait)
+				if !yield(step.value, step.value) {
+					return
+				}
+			}
+		})
+
+		result := DistinctTTL(events, 10*time.Second, WithClock(clock)).Collect()
+
+		// a at 0s, b at 9s, a again at 10s, b again at 20s
+		expected := []string{"a", "b", "a", "b"}
+		if !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+}
+
+func TestMapToTimed(t *testing.T) {
+	t.Run("Reports one timing per element", func(t *testing.T) {
+		clock := internal.NewFakeClock(clockStart)
+		var timings []time.Duration
+
+		result := MapToTimed(Range(1, 4), func(n int) int {
+			clock.Advance(time.Duration(n) * time.Second)
+			return n * 10
+		}, func(d time.Duration) {
+			timings = append(timings, d)
+		}, WithClock(clock)).Collect()
+
+		if expected := []int{10, 20, 30}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		if expected := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}; !slices.Equal(timings, expected) {
+			t.Errorf("Expected %v, got %v", expected, timings)
+		}
+	})
+
+	t.Run("Stops timing when consumer stops", func(t *testing.T) {
+		calls := 0
+		MapToTimed(Range(0, 100), func(n int) int { return n }, func(time.Duration) { calls++ }).Take(3).Collect()
+
+		if calls != 3 {
+			t.Errorf("Expected 3 timings, got %d", calls)
+		}
+	})
+}
+
+func TestCollectBounded(t *testing.T) {
+	t.Run("Count limit", func(t *testing.T) {
+		result, complete := Infinite(func(i int) int { return i }).CollectBounded(5, time.Minute)
+
+		if expected := []int{0, 1, 2, 3, 4}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		if complete {
+			t.Error("Expected a count-limited flow to report incomplete")
+		}
+	})
+
+	t.Run("Time limit", func(t *testing.T) {
+		clock := internal.NewFakeClock(clockStart)
+		sent := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		live := FromFunc(func(yield func(int, int) bool) {
+			for i := range 2 {
+				if !yield(i, i) {
+					return
+				}
+			}
+			close(sent)
+			<-release // idle like a live source waiting for input
+		})
+
+		type outcome struct {
+			result   []int
+			complete bool
+		}
+		done := make(chan outcome)
+		go func() {
+			result, complete := live.CollectBounded(10, time.Minute, WithClock(clock))
+			done <- outcome{result, complete}
+		}()
+
+		clock.BlockUntil(1)
+		<-sent
+		clock.Advance(time.Minute)
+		got := <-done
+
+		if expected := []int{0, 1}; !slices.Equal(got.result, expected) {
+			t.Errorf("Expected %v, got %v", expected, got.result)
+		}
+		if got.complete {
+			t.Error("Expected a time-limited flow to report incomplete")
+		}
+	})
+
+	t.Run("Completes naturally", func(t *testing.T) {
+		result, complete := Range(0, 3).CollectBounded(10, time.Minute)
+
+		if expected := []int{0, 1, 2}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		if !complete {
+			t.Error("Expected a finished flow to report complete")
+		}
+	})
+
+	t.Run("Panics on non-positive count", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for zero max count")
+			}
+		}()
+		Range(0, 3).CollectBounded(0, time.Second)
+	})
+}
+
+func TestWithHeartbeat(t *testing.T) {
+	t.Run("Heartbeats fill gaps in a slow source", func(t *testing.T) {
+		source := make(chan int)
+		go func() {
+			defer close(source)
+			source <- 1
+			time.Sleep(40 * time.Millisecond)
+			source <- 2
+		}()
+
+		result := WithHeartbeat(FromChannel(source), 5*time.Millisecond, 0).Collect()
+
+		if len(result) < 3 || result[0] != 1 || result[len(result)-1] != 2 {
+			t.Fatalf("Expected 1, heartbeats, then 2, got %v", result)
+		}
+		for _, v := range result[1 : len(result)-1] {
+			if v != 0 {
+				t.Errorf("Expected only heartbeats during the gap, got %v", result)
+			}
+		}
+	})
+
+	t.Run("Fake clock", func(t *testing.T) {
+		clock := internal.NewFakeClock(clockStart)
+		source := make(chan string)
+		out := WithHeartbeat(FromChannel(source), time.Second, "hb", WithClock(clock)).ToChannel(0)
+
+		var result []string
+		clock.BlockUntil(1)
+		source <- "a"
+		result = append(result, <-out)
+		for range 2 {
+			clock.BlockUntil(1)
+			clock.Advance(time.Second)
+			result = append(result, <-out)
+		}
+		source <- "b"
+		result = append(result, <-out)
+		close(source)
+		for v := range out {
+			result = append(result, v)
+		}
+
+		if expected := []string{"a", "hb", "hb", "b"}; !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Busy source gets no heartbeats", func(t *testing.T) {
+		result := WithHeartbeat(Range(0, 100), time.Minute, -1).Collect()
+
+		if !slices.Equal(result, Range(0, 100).Collect()) {
+			t.Errorf("Expected the source elements only, got %v", result)
+		}
+	})
+}
@@ -0,0 +1,324 @@
+package flow
+
+import (
+	"math"
+	"time"
+
+	"github.com/MirrexOne/Flow/internal"
+)
+
+// Clock is the source of time used by time-based operations.
+// Supply a custom implementation with WithClock, for example a fake clock in tests.
+type Clock = internal.Clock
+
+// TimeOption configures a time-based operation such as Tick, TakeFor, SkipFor,
+// DistinctTTL, MapToTimed, CollectBounded or WithHeartbeat.
+type TimeOption func(*timeConfig)
+
+type timeConfig struct {
+	clock Clock
+}
+
+// WithClock makes a time-based operation read time from clock instead of the system clock.
+//
+// Example:
+//
+//	// testClock implements flow.Clock and only advances when told to
+//	ticks := flow.Tick(time.Second, flow.WithClock(testClock))
+func WithClock(clock Clock) TimeOption {
+	return func(c *timeConfig) {
+		c.clock = clock
+	}
+}
+
+func newTimeConfig(opts []TimeOption) timeConfig {
+	config := timeConfig{clock: internal.RealClock}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// Tick creates an infinite Flow that yields the current time every interval d.
+// The underlying ticker is started when the flow is consumed and stopped as soon
+// as the consumer stops, so limit the flow with Take or similar operations.
+//
+// Example:
+//
+//	flow.Tick(time.Second).Take(3).ForEach(func(t time.Time) {
+//	    fmt.Println("tick at", t)
+//	})
+func Tick(d time.Duration, opts ...TimeOption) Flow[time.Time, time.Time] {
+	if d <= 0 {
+		panic("tick interval must be positive")
+	}
+	config := newTimeConfig(opts)
+
+	return Flow[time.Time, time.Time]{
+		source: func(yield func(time.Time, time.Time) bool) {
+			ticks, stop := config.clock.NewTicker(d)
+			defer stop()
+			for t := range ticks {
+				if !yield(t, t) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// Backoff creates an infinite Flow of exponentially increasing delays.
+// It yields base, base*factor, base*factor², ... with every delay capped at max.
+// Once the cap is reached, max is yielded indefinitely.
+// Panics if base is not positive, if factor is below 1 or NaN, or if max is less than base.
+//
+// Example:
+//
+//	for _, delay := range flow.Backoff(100*time.Millisecond, 2, 5*time.Second).Take(5).Collect() {
+//	    if err := attempt(); err == nil {
+//	        break
+//	    }
+//	    time.Sleep(delay)
+//	}
+func Backoff(base time.Duration, factor float64, max time.Duration) Flow[time.Duration, time.Duration] {
+	if base <= 0 {
+		panic("backoff base must be positive")
+	}
+	if factor < 1 || math.IsNaN(factor) {
+		panic("backoff factor must be at least 1")
+	}
+	if max < base {
+		panic("backoff max must not be less than base")
+	}
+
+	return Flow[time.Duration, time.Duration]{
+		source: func(yield func(time.Duration, time.Duration) bool) {
+			current := float64(base)
+			for {
+				delay := max
+				if current < float64(max) {
+					delay = time.Duration(current)
+					current *= factor
+				}
+				if !yield(delay, delay) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// MapToTimed transforms each element like MapTo and reports how long every mapper
+// call took via onTiming before passing the result downstream.
+// Time spent downstream is not included. Useful for finding slow elements.
+//
+// Example:
+//
+//	var slowest time.Duration
+//	results := flow.MapToTimed(requests, handle, func(d time.Duration) {
+//	    slowest = max(slowest, d)
+//	}).Collect()
+func MapToTimed[T, U, R any](f Flow[T, R], mapper func(T) U, onTiming func(d time.Duration), opts ...TimeOption) Flow[U, U] {
+	config := newTimeConfig(opts)
+	return Flow[U, U]{
+		source: func(yield func(U, U) bool) {
+			for k := range f.source {
+				start := config.clock.Now()
+				res := mapper(k)
+				onTiming(config.clock.Now().Sub(start))
+				if !yield(res, res) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// TakeFor yields elements until d has elapsed since the flow started being consumed.
+// The source is consumed in a separate goroutine, so the flow ends on time even
+// when a channel-backed source is waiting for its next element. A goroutine
+// blocked inside such a source exits once the source produces or closes.
+//
+// Example:
+//
+//	// Collect events for five seconds
+//	events := flow.TakeFor(flow.FromChannel(eventCh), 5*time.Second).Collect()
+func TakeFor[T, R any](f Flow[T, R], d time.Duration, opts ...TimeOption) Flow[T, R] {
+	config := newTimeConfig(opts)
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			deadline := config.clock.After(d)
+
+			done := make(chan struct{})
+			defer close(done)
+			elements := make(chan Pair[T, R])
+			go pump(asPairs(f), elements, done)
+
+			for {
+				select {
+				case <-deadline:
+					return
+				case pair, ok := <-elements:
+					if !ok {
+						return
+					}
+					if !yield(pair.First, pair.Second) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// CollectBounded gathers elements until maxCount elements have been collected or
+// maxWait has elapsed, whichever comes first. The returned bool reports whether
+// the flow ended on its own before either limit was hit; a flow stopped by a limit,
+// including one with exactly maxCount elements, reports false.
+// As with TakeFor, the source is consumed in a separate goroutine so a waiting
+// channel-backed source cannot hold the caller past maxWait.
+// Panics if maxCount is not positive.
+// This is a TERMINAL operation - it consumes at most maxCount elements.
+//
+// Example:
+//
+//	batch, complete := flow.FromChannel(untrusted).CollectBounded(1000, time.Second)
+//	if !complete {
+//	    log.Printf("truncated input after %d elements", len(batch))
+//	}
+func (f Flow[T, R]) CollectBounded(maxCount int, maxWait time.Duration, opts ...TimeOption) ([]T, bool) {
+	if maxCount <= 0 {
+		panic("max count must be positive")
+	}
+	config := newTimeConfig(opts)
+	deadline := config.clock.After(maxWait)
+
+	done := make(chan struct{})
+	defer close(done)
+	elements := make(chan T)
+	go pump(f, elements, done)
+
+	result := make([]T, 0, min(maxCount, 16))
+	for {
+		select {
+		case <-deadline:
+			return result, false
+		case val, ok := <-elements:
+			if !ok {
+				return result, true
+			}
+			result = append(result, val)
+			if len(result) == maxCount {
+				return result, false
+			}
+		}
+	}
+}
+
+// WithHeartbeat yields the elements of f and injects heartbeat whenever no element
+// has arrived for interval, which keeps downstream connections alive during gaps
+// in a live stream. The wait restarts after every element and every heartbeat, so a
+// long gap produces one heartbeat per interval. The flow ends when f ends.
+// As with TakeFor, f is consumed in a separate goroutine, so heartbeats are emitted
+// even while a channel-backed source is waiting for its next element.
+//
+// Example:
+//
+//	// Send a keep-alive comment if no event was sent for 15 seconds
+//	events := flow.WithHeartbeat(flow.FromChannel(eventCh), 15*time.Second, ": keep-alive")
+func WithHeartbeat[T, R any](f Flow[T, R], interval time.Duration, heartbeat T, opts ...TimeOption) Flow[T, T] {
+	if interval <= 0 {
+		panic("heartbeat interval must be positive")
+	}
+	config := newTimeConfig(opts)
+
+	return Flow[T, T]{
+		source: func(yield func(T, T) bool) {
+			done := make(chan struct{})
+			defer close(done)
+			elements := make(chan T)
+			go pump(f, elements, done)
+
+			for {
+				idle, stop := config.clock.NewTimer(interval)
+				select {
+				case val, ok := <-elements:
+					stop()
+					if !ok {
+						return
+					}
+					if !yield(val, val) {
+						return
+					}
+				case <-idle:
+					if !yield(heartbeat, heartbeat) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// SkipFor discards elements that arrive within d of the flow starting to be consumed,
+// then yields all remaining elements. Useful for ignoring a warm-up period in a live stream.
+//
+// Example:
+//
+//	// Ignore readings from the first ten seconds after startup
+//	stable := flow.SkipFor(flow.FromChannel(readings), 10*time.Second)
+func SkipFor[T, R any](f Flow[T, R], d time.Duration, opts ...TimeOption) Flow[T, R] {
+	config := newTimeConfig(opts)
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			start := config.clock.Now()
+			skipping := true
+			for k, v := range f.source {
+				if skipping && config.clock.Now().Sub(start) < d {
+					continue
+				}
+				skipping = false
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// DistinctTTL drops elements equal to one emitted less than ttl ago.
+// Once ttl has passed since a value was last emitted, the value is emitted again.
+// Expired entries are evicted as new elements arrive, so memory is bounded by
+// the number of distinct values emitted within one ttl.
+// This suits deduplicating events on channel-backed live flows.
+//
+// Example:
+//
+//	alerts := flow.DistinctTTL(flow.FromChannel(alertCh), time.Minute)
+func DistinctTTL[T comparable, R any](f Flow[T, R], ttl time.Duration, opts ...TimeOption) Flow[T, R] {
+	config := newTimeConfig(opts)
+	return Flow[T, R]{
+		source: func(yield func(T, R) bool) {
+			emitted := make(map[T]time.Time)
+			var queue []KeyValue[T, time.Time]
+			for k, v := range f.source {
+				now := config.clock.Now()
+				for len(queue) > 0 && now.Sub(queue[0].Value) >= ttl {
+					oldest := queue[0]
+					if emitted[oldest.Key].Equal(oldest.Value) {
+						delete(emitted, oldest.Key)
+					}
+					queue = queue[1:]
+				}
+				if _, ok := emitted[k]; ok {
+					continue
+				}
+				emitted[k] = now
+				queue = append(queue, KeyValue[T, time.Time]{Key: k, Value: now})
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
+}